@@ -1,10 +1,10 @@
 package config
 
 import (
-	"bufio"
+	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
+	"sort"
 	"sync"
 
 	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud"
@@ -28,7 +28,17 @@ type Config struct {
 
 	// Track source of each credential for debugging
 	sources map[string]string
-	mu      sync.RWMutex
+	// Problems found while parsing the credentials file
+	fileDiagnostics []Diagnostic
+	// Selected credentials file profile, its source_profile chain, and
+	// which profile in the chain supplied each file-sourced credential
+	profile      string
+	profileChain []string
+	profileOf    map[string]string
+	// On-disk Identity token cache, see EnableIdentityTokenCache
+	tokenCache *IdentityTokenCache
+
+	mu sync.RWMutex
 }
 
 // CredentialSource indicates where a credential was loaded from
@@ -44,7 +54,8 @@ const (
 // Load creates a new Config with priority: file > env > interactive (empty initially)
 func Load() *Config {
 	cfg := &Config{
-		sources: make(map[string]string),
+		sources:   make(map[string]string),
+		profileOf: make(map[string]string),
 	}
 
 	// 1. Load from credentials file first
@@ -56,83 +67,117 @@ func Load() *Config {
 	return cfg
 }
 
-// loadFromFile loads credentials from ~/.nhncloud/credentials
-func (c *Config) loadFromFile() {
-	configPath := filepath.Join(os.Getenv("HOME"), ".nhncloud", "credentials")
-	file, err := os.Open(configPath)
-	if err != nil {
-		return
-	}
-	defer file.Close()
+// CredentialKey describes how a credential is named in the credentials file
+// and in the environment
+type CredentialKey struct {
+	Name    string // Config field name, also used as the sources key
+	FileKey string // key in ~/.nhncloud/credentials
+	EnvVar  string // overriding environment variable
+	Secret  bool   // value must never appear in tool output
+}
 
-	scanner := bufio.NewScanner(file)
-	inDefaultProfile := false
+// credentialKeys lists every credential the server understands
+var credentialKeys = []CredentialKey{
+	{"AccessKeyID", "access_key_id", "NHN_CLOUD_ACCESS_KEY_ID", true},
+	{"SecretAccessKey", "secret_access_key", "NHN_CLOUD_SECRET_ACCESS_KEY", true},
+	{"Region", "region", "NHN_CLOUD_REGION", false},
+	{"MySQLAppKey", "rds_app_key", "NHN_CLOUD_MYSQL_APPKEY", true},
+	{"MariaDBAppKey", "rds_mariadb_app_key", "NHN_CLOUD_MARIADB_APPKEY", true},
+	{"PostgreSQLAppKey", "rds_postgresql_app_key", "NHN_CLOUD_POSTGRESQL_APPKEY", true},
+	{"Username", "username", "NHN_CLOUD_USERNAME", false},
+	{"Password", "api_password", "NHN_CLOUD_PASSWORD", true},
+	{"TenantID", "tenant_id", "NHN_CLOUD_TENANT_ID", false},
+	{"NKSTenantID", "nks_tenant_id", "NHN_CLOUD_NKS_TENANT_ID", false},
+	{"OBSTenantID", "obs_tenant_id", "NHN_CLOUD_OBS_TENANT_ID", false},
+}
 
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+// CredentialKeys returns the known credentials in display order
+func CredentialKeys() []CredentialKey {
+	return append([]CredentialKey(nil), credentialKeys...)
+}
 
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
+// LookupCredential returns the key description for a credential name
+func LookupCredential(name string) (CredentialKey, bool) {
+	for _, k := range credentialKeys {
+		if k.Name == name {
+			return k, true
 		}
+	}
+	return CredentialKey{}, false
+}
 
-		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
-			profile := strings.TrimPrefix(strings.TrimSuffix(line, "]"), "[")
-			inDefaultProfile = (profile == "default")
-			continue
-		}
+// CredentialsPath returns the path of the shared credentials file
+func CredentialsPath() string {
+	return filepath.Join(os.Getenv("HOME"), ".nhncloud", "credentials")
+}
 
-		if !inDefaultProfile {
-			continue
-		}
+// loadFromFile loads credentials from ~/.nhncloud/credentials.
+// The profile is chosen by NHN_CLOUD_PROFILE (default: "default"); keys it
+// does not set are inherited along its source_profile chain.
+func (c *Config) loadFromFile() {
+	c.profile = activeProfile()
+
+	f, diags, err := readCredentialsFile(CredentialsPath())
+	c.fileDiagnostics = diags
+	if err != nil && !os.IsNotExist(err) {
+		c.fileDiagnostics = append(c.fileDiagnostics, Diagnostic{Severity: SeverityError, Message: err.Error()})
+	}
+	if f == nil {
+		return
+	}
 
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) != 2 {
-			continue
+	chain, chainDiags := resolveProfileChain(f, c.profile)
+	c.fileDiagnostics = append(c.fileDiagnostics, chainDiags...)
+	c.profileChain = profileNames(chain)
+
+	// Nearest profile wins: setIfEmpty ignores values already set by a child
+	for _, profile := range chain {
+		for _, e := range profile.Entries {
+			k, ok := lookupFileKey(e.Key)
+			if !ok {
+				continue
+			}
+			if c.setIfEmpty(k.Name, c.field(k.Name), e.Value, string(SourceFile)) {
+				c.profileOf[k.Name] = profile.Name
+			}
 		}
+	}
+}
+
+// readCredentialsFile parses the credentials file and flags unknown keys
+func readCredentialsFile(path string) (*iniFile, []Diagnostic, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	f, diags, err := parseINI(file)
+	if err != nil {
+		return nil, diags, err
+	}
 
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-
-		switch key {
-		case "access_key_id":
-			c.setIfEmpty("AccessKeyID", &c.AccessKeyID, value, string(SourceFile))
-		case "secret_access_key":
-			c.setIfEmpty("SecretAccessKey", &c.SecretAccessKey, value, string(SourceFile))
-		case "region":
-			c.setIfEmpty("Region", &c.Region, value, string(SourceFile))
-		case "username":
-			c.setIfEmpty("Username", &c.Username, value, string(SourceFile))
-		case "api_password":
-			c.setIfEmpty("Password", &c.Password, value, string(SourceFile))
-		case "tenant_id":
-			c.setIfEmpty("TenantID", &c.TenantID, value, string(SourceFile))
-		case "nks_tenant_id":
-			c.setIfEmpty("NKSTenantID", &c.NKSTenantID, value, string(SourceFile))
-		case "obs_tenant_id":
-			c.setIfEmpty("OBSTenantID", &c.OBSTenantID, value, string(SourceFile))
-		case "rds_app_key":
-			c.setIfEmpty("MySQLAppKey", &c.MySQLAppKey, value, string(SourceFile))
-		case "rds_mariadb_app_key":
-			c.setIfEmpty("MariaDBAppKey", &c.MariaDBAppKey, value, string(SourceFile))
-		case "rds_postgresql_app_key":
-			c.setIfEmpty("PostgreSQLAppKey", &c.PostgreSQLAppKey, value, string(SourceFile))
+	for _, s := range f.Sections {
+		for _, e := range s.Entries {
+			if _, ok := lookupFileKey(e.Key); !ok && e.Key != sourceProfileKey {
+				diags = append(diags, Diagnostic{
+					Line:     e.Line,
+					Severity: SeverityWarning,
+					Message:  fmt.Sprintf("unknown key %q in [%s] is ignored", e.Key, s.Name),
+				})
+			}
 		}
 	}
+
+	sort.SliceStable(diags, func(i, j int) bool { return diags[i].Line < diags[j].Line })
+	return f, diags, nil
 }
 
 // loadFromEnv overrides with environment variables if set
 func (c *Config) loadFromEnv() {
-	c.setFromEnv("Region", &c.Region, "NHN_CLOUD_REGION")
-	c.setFromEnv("AccessKeyID", &c.AccessKeyID, "NHN_CLOUD_ACCESS_KEY_ID")
-	c.setFromEnv("SecretAccessKey", &c.SecretAccessKey, "NHN_CLOUD_SECRET_ACCESS_KEY")
-	c.setFromEnv("MySQLAppKey", &c.MySQLAppKey, "NHN_CLOUD_MYSQL_APPKEY")
-	c.setFromEnv("MariaDBAppKey", &c.MariaDBAppKey, "NHN_CLOUD_MARIADB_APPKEY")
-	c.setFromEnv("PostgreSQLAppKey", &c.PostgreSQLAppKey, "NHN_CLOUD_POSTGRESQL_APPKEY")
-	c.setFromEnv("Username", &c.Username, "NHN_CLOUD_USERNAME")
-	c.setFromEnv("Password", &c.Password, "NHN_CLOUD_PASSWORD")
-	c.setFromEnv("TenantID", &c.TenantID, "NHN_CLOUD_TENANT_ID")
-	c.setFromEnv("NKSTenantID", &c.NKSTenantID, "NHN_CLOUD_NKS_TENANT_ID")
-	c.setFromEnv("OBSTenantID", &c.OBSTenantID, "NHN_CLOUD_OBS_TENANT_ID")
+	for _, k := range credentialKeys {
+		c.setFromEnv(k.Name, c.field(k.Name), k.EnvVar)
+	}
 
 	// Default region if not set
 	if c.Region == "" {
@@ -208,6 +253,9 @@ func (c *Config) GetStatus() map[string]map[string]string {
 			"configured": configured,
 			"source":     source,
 		}
+		if source == string(SourceFile) {
+			status[name]["profile"] = c.profileOf[name]
+		}
 	}
 
 	check("AccessKeyID", c.AccessKeyID)
@@ -223,6 +271,28 @@ func (c *Config) GetStatus() map[string]map[string]string {
 	return status
 }
 
+// Profile returns the credentials file profile selected at load time
+func (c *Config) Profile() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.profile
+}
+
+// ProfileChain returns the selected profile followed by the profiles it
+// inherits from via source_profile
+func (c *Config) ProfileChain() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]string(nil), c.profileChain...)
+}
+
+// FileDiagnostics returns the problems found while parsing the credentials file
+func (c *Config) FileDiagnostics() []Diagnostic {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]Diagnostic(nil), c.fileDiagnostics...)
+}
+
 // NewNHNCloudClient creates a new NHN Cloud SDK client
 func (c *Config) NewNHNCloudClient() (*nhncloud.Client, error) {
 	c.mu.RLock()
@@ -249,6 +319,48 @@ func (c *Config) NewNHNCloudClient() (*nhncloud.Client, error) {
 	return nhncloud.New(cfg)
 }
 
+// MySQLAPICredentials returns the region and credentials for calling the
+// RDS for MySQL API directly, for endpoints the SDK does not cover
+func (c *Config) MySQLAPICredentials() (region, appKey, accessKeyID, secretAccessKey string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Region, c.MySQLAppKey, c.AccessKeyID, c.SecretAccessKey
+}
+
+// ObjectStorageCredentials returns the region, tenant and API user for Object
+// Storage. The tenant is NHN_CLOUD_OBS_TENANT_ID when set, else the tenant ID.
+func (c *Config) ObjectStorageCredentials() (region, tenantID, username, password string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	tenantID = c.OBSTenantID
+	if tenantID == "" {
+		tenantID = c.TenantID
+	}
+	return c.Region, tenantID, c.Username, c.Password
+}
+
+// ComputeCredentials returns the region, tenant and API user for the
+// Compute API
+func (c *Config) ComputeCredentials() (region, tenantID, username, password string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Region, c.TenantID, c.Username, c.Password
+}
+
+// SecretValues returns the configured values of all secret credentials
+func (c *Config) SecretValues() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var values []string
+	for _, k := range credentialKeys {
+		if v := *c.field(k.Name); k.Secret && v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
 // HasRDSCredentials checks if RDS credentials are configured
 func (c *Config) HasRDSCredentials() bool {
 	c.mu.RLock()
@@ -265,11 +377,51 @@ func (c *Config) HasComputeCredentials() bool {
 
 // helper functions
 
-func (c *Config) setIfEmpty(name string, field *string, value, source string) {
+// field returns a pointer to the Config field backing the named credential
+func (c *Config) field(name string) *string {
+	switch name {
+	case "AccessKeyID":
+		return &c.AccessKeyID
+	case "SecretAccessKey":
+		return &c.SecretAccessKey
+	case "Region":
+		return &c.Region
+	case "MySQLAppKey":
+		return &c.MySQLAppKey
+	case "MariaDBAppKey":
+		return &c.MariaDBAppKey
+	case "PostgreSQLAppKey":
+		return &c.PostgreSQLAppKey
+	case "Username":
+		return &c.Username
+	case "Password":
+		return &c.Password
+	case "TenantID":
+		return &c.TenantID
+	case "NKSTenantID":
+		return &c.NKSTenantID
+	case "OBSTenantID":
+		return &c.OBSTenantID
+	}
+	return nil
+}
+
+func lookupFileKey(fileKey string) (CredentialKey, bool) {
+	for _, k := range credentialKeys {
+		if k.FileKey == fileKey {
+			return k, true
+		}
+	}
+	return CredentialKey{}, false
+}
+
+func (c *Config) setIfEmpty(name string, field *string, value, source string) bool {
 	if *field == "" && value != "" {
 		*field = value
 		c.sources[name] = source
+		return true
 	}
+	return false
 }
 
 func (c *Config) setFromEnv(name string, field *string, envKey string) {
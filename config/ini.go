@@ -0,0 +1,240 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Diagnostic severities
+const (
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// Diagnostic is a problem found while parsing the credentials file.
+// Errors mean the line was ignored; warnings mean it was used with caveats.
+type Diagnostic struct {
+	Line     int
+	Severity string
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	if d.Line == 0 {
+		return fmt.Sprintf("%s: %s", d.Severity, d.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", d.Line, d.Severity, d.Message)
+}
+
+// iniEntry is a single key/value pair in a section
+type iniEntry struct {
+	Key   string
+	Value string
+	Line  int
+}
+
+// iniSection is a [section] and its entries in file order
+type iniSection struct {
+	Name    string
+	Line    int
+	Entries []iniEntry
+}
+
+// Get returns the value of key and whether it was present
+func (s *iniSection) Get(key string) (string, bool) {
+	for _, e := range s.Entries {
+		if e.Key == key {
+			return e.Value, true
+		}
+	}
+	return "", false
+}
+
+func (s *iniSection) entry(key string) *iniEntry {
+	for i := range s.Entries {
+		if s.Entries[i].Key == key {
+			return &s.Entries[i]
+		}
+	}
+	return nil
+}
+
+// iniFile is a parsed INI document
+type iniFile struct {
+	Sections []*iniSection
+}
+
+// Section returns the named section, or nil
+func (f *iniFile) Section(name string) *iniSection {
+	for _, s := range f.Sections {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// parseINI parses the credentials file format.
+//
+// Supported syntax:
+//   - [section] headers; repeated sections are merged
+//   - key = value and key: value
+//   - full-line comments starting with # or ;
+//   - inline comments after unquoted values, introduced by whitespace and # or ;
+//   - "double quoted" values with \\, \", \n, \t and \r escapes
+//   - 'single quoted' values taken literally
+//
+// Duplicate keys keep the first value, matching the original loader.
+func parseINI(r io.Reader) (*iniFile, []Diagnostic, error) {
+	f := &iniFile{}
+	var diags []Diagnostic
+	var current *iniSection
+
+	report := func(line int, severity, format string, args ...any) {
+		diags = append(diags, Diagnostic{Line: line, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if lineNo == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if line[0] == '[' {
+			end := strings.IndexByte(line, ']')
+			if end < 0 {
+				report(lineNo, SeverityError, "unterminated section header %q", line)
+				current = nil
+				continue
+			}
+			if rest := strings.TrimSpace(line[end+1:]); rest != "" && rest[0] != '#' && rest[0] != ';' {
+				report(lineNo, SeverityWarning, "unexpected text after section header: %q", rest)
+			}
+			name := strings.TrimSpace(line[1:end])
+			if name == "" {
+				report(lineNo, SeverityError, "empty section name")
+				current = nil
+				continue
+			}
+			if existing := f.Section(name); existing != nil {
+				report(lineNo, SeverityWarning, "section [%s] already defined on line %d; entries are merged", name, existing.Line)
+				current = existing
+				continue
+			}
+			current = &iniSection{Name: name, Line: lineNo}
+			f.Sections = append(f.Sections, current)
+			continue
+		}
+
+		sep := strings.IndexAny(line, "=:")
+		if sep < 0 {
+			report(lineNo, SeverityError, "expected key = value, got %q", line)
+			continue
+		}
+
+		key := strings.TrimSpace(line[:sep])
+		if key == "" {
+			report(lineNo, SeverityError, "missing key before %q", string(line[sep]))
+			continue
+		}
+
+		value, warning, err := parseINIValue(strings.TrimSpace(line[sep+1:]))
+		if err != nil {
+			report(lineNo, SeverityError, "%s: %v", key, err)
+			continue
+		}
+		if warning != "" {
+			report(lineNo, SeverityWarning, "%s: %s", key, warning)
+		}
+
+		if current == nil {
+			report(lineNo, SeverityError, "key %q is outside of any [profile] section", key)
+			continue
+		}
+
+		if prev := current.entry(key); prev != nil {
+			report(lineNo, SeverityWarning, "duplicate key %q in [%s] (first set on line %d); this value is ignored", key, current.Name, prev.Line)
+			continue
+		}
+		current.Entries = append(current.Entries, iniEntry{Key: key, Value: value, Line: lineNo})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return f, diags, err
+	}
+	return f, diags, nil
+}
+
+// parseINIValue decodes the raw text after the key separator
+func parseINIValue(raw string) (value, warning string, err error) {
+	if raw == "" {
+		return "", "", nil
+	}
+
+	switch raw[0] {
+	case '"':
+		var b strings.Builder
+		for i := 1; i < len(raw); i++ {
+			c := raw[i]
+			switch {
+			case c == '\\' && i+1 < len(raw):
+				i++
+				switch raw[i] {
+				case '\\', '"':
+					b.WriteByte(raw[i])
+				case 'n':
+					b.WriteByte('\n')
+				case 't':
+					b.WriteByte('\t')
+				case 'r':
+					b.WriteByte('\r')
+				default:
+					b.WriteByte('\\')
+					b.WriteByte(raw[i])
+					warning = fmt.Sprintf("unknown escape sequence \\%c kept as is", raw[i])
+				}
+			case c == '"':
+				if w := trailingText(raw[i+1:]); w != "" {
+					warning = w
+				}
+				return b.String(), warning, nil
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", "", fmt.Errorf("unterminated double-quoted value")
+
+	case '\'':
+		end := strings.IndexByte(raw[1:], '\'')
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated single-quoted value")
+		}
+		return raw[1 : end+1], trailingText(raw[end+2:]), nil
+	}
+
+	// Unquoted: strip an inline comment introduced by whitespace
+	for i := 1; i < len(raw); i++ {
+		if (raw[i] == '#' || raw[i] == ';') && (raw[i-1] == ' ' || raw[i-1] == '\t') {
+			return strings.TrimSpace(raw[:i]), "", nil
+		}
+	}
+	return raw, "", nil
+}
+
+// trailingText returns a warning if anything other than a comment follows a quoted value
+func trailingText(rest string) string {
+	rest = strings.TrimSpace(rest)
+	if rest == "" || rest[0] == '#' || rest[0] == ';' {
+		return ""
+	}
+	return fmt.Sprintf("text after closing quote ignored: %q", rest)
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseINIValue(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		warning bool
+		err     bool
+	}{
+		{raw: "", want: ""},
+		{raw: "plain", want: "plain"},
+		{raw: "abc#def", want: "abc#def"},
+		{raw: "abc;def", want: "abc;def"},
+		{raw: "abc # comment", want: "abc"},
+		{raw: "abc\t; comment", want: "abc"},
+		{raw: `"a # b ; c"`, want: "a # b ; c"},
+		{raw: `"a # b" # comment`, want: "a # b"},
+		{raw: `'a ; b'`, want: "a ; b"},
+		{raw: `'a\nb'`, want: `a\nb`},
+		{raw: `"a\"b\\c"`, want: `a"b\c`},
+		{raw: `"line\nnext\ttab\r"`, want: "line\nnext\ttab\r"},
+		{raw: `"  padded  "`, want: "  padded  "},
+		{raw: `"a\qb"`, want: `a\qb`, warning: true},
+		{raw: `"value" trailing`, want: "value", warning: true},
+		{raw: `"unterminated`, err: true},
+		{raw: `'unterminated`, err: true},
+	}
+	for _, tt := range tests {
+		got, warning, err := parseINIValue(tt.raw)
+		if (err != nil) != tt.err {
+			t.Errorf("parseINIValue(%q) error = %v, want error %v", tt.raw, err, tt.err)
+			continue
+		}
+		if got != tt.want || (warning != "") != tt.warning {
+			t.Errorf("parseINIValue(%q) = %q, warning %q; want %q, warning %v", tt.raw, got, warning, tt.want, tt.warning)
+		}
+	}
+}
+
+func TestParseINI(t *testing.T) {
+	input := strings.Join([]string{
+		"\ufeff# leading comment",        // 1
+		"[default]",                      // 2
+		"region = kr1",                   // 3
+		"access_key_id: key: with colon", // 4
+		"url = http://host:80/?a=b",      // 5
+		"a:b = c",                        // 6
+		"region = kr2",                   // 7
+		"no separator",                   // 8
+		" = missing key",                 // 9
+		"",                               // 10
+		"[dev]",                          // 11
+		`password = "p#ss;word"`,         // 12
+		"[default]",                      // 13
+		"tenant_id = t1",                 // 14
+		"[broken",                        // 15
+	}, "\n")
+
+	f, diags, err := parseINI(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := f.Section("default")
+	if def == nil || def.Line != 2 {
+		t.Fatalf("[default] = %+v", def)
+	}
+	for key, want := range map[string]string{
+		"region":        "kr1", // duplicate on line 7 keeps the first value
+		"access_key_id": "key: with colon",
+		"url":           "http://host:80/?a=b",
+		"a":             "b = c", // the first ':' or '=' separates key and value
+		"tenant_id":     "t1",    // repeated section is merged
+	} {
+		if got, ok := def.Get(key); !ok || got != want {
+			t.Errorf("[default] %s = %q (%v), want %q", key, got, ok, want)
+		}
+	}
+	if got, _ := f.Section("dev").Get("password"); got != "p#ss;word" {
+		t.Errorf("[dev] password = %q", got)
+	}
+
+	want := []Diagnostic{
+		{Line: 7, Severity: SeverityWarning},
+		{Line: 8, Severity: SeverityError},
+		{Line: 9, Severity: SeverityError},
+		{Line: 13, Severity: SeverityWarning},
+		{Line: 15, Severity: SeverityError},
+	}
+	if len(diags) != len(want) {
+		t.Fatalf("got diagnostics %v, want %d", diags, len(want))
+	}
+	for i, d := range diags {
+		if d.Line != want[i].Line || d.Severity != want[i].Severity {
+			t.Errorf("diagnostic %d = %v, want line %d %s", i, d, want[i].Line, want[i].Severity)
+		}
+	}
+	if !strings.Contains(diags[0].Message, "first set on line 3") {
+		t.Errorf("duplicate key diagnostic = %q", diags[0].Message)
+	}
+}
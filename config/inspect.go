@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Recommended permissions for the credentials file and its directory
+const (
+	RecommendedFileMode os.FileMode = 0600
+	RecommendedDirMode  os.FileMode = 0700
+)
+
+// FileReport describes the credentials file as the loader sees it
+type FileReport struct {
+	Path    string
+	Exists  bool
+	Mode    os.FileMode
+	DirPath string
+	DirMode os.FileMode
+
+	// ReadError is set when the file exists but could not be read
+	ReadError error
+
+	// Profiles lists every profile section in file order
+	Profiles []string
+	// ActiveProfile is the profile selected by NHN_CLOUD_PROFILE
+	ActiveProfile string
+	// ProfileChain is ActiveProfile followed by its source_profile ancestors
+	ProfileChain []string
+	// ProfileKeys holds the known keys set anywhere in ProfileChain
+	ProfileKeys map[string]bool
+	// Diagnostics lists parse problems and unknown keys by line
+	Diagnostics []Diagnostic
+}
+
+// IgnoredProfiles returns profiles that are neither the active profile nor
+// inherited from by it
+func (r *FileReport) IgnoredProfiles() []string {
+	used := make(map[string]bool, len(r.ProfileChain))
+	for _, p := range r.ProfileChain {
+		used[p] = true
+	}
+
+	var ignored []string
+	for _, p := range r.Profiles {
+		if !used[p] {
+			ignored = append(ignored, p)
+		}
+	}
+	return ignored
+}
+
+// InspectCredentialsFile examines ~/.nhncloud/credentials without loading it
+func InspectCredentialsFile() *FileReport {
+	path := CredentialsPath()
+	report := &FileReport{
+		Path:          path,
+		DirPath:       filepath.Dir(path),
+		ActiveProfile: activeProfile(),
+		ProfileKeys:   make(map[string]bool),
+	}
+
+	if info, err := os.Stat(report.DirPath); err == nil {
+		report.DirMode = info.Mode().Perm()
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return report
+	}
+	report.Exists = true
+	report.Mode = info.Mode().Perm()
+
+	f, diags, err := readCredentialsFile(path)
+	report.Diagnostics = diags
+	if err != nil {
+		report.ReadError = err
+		return report
+	}
+
+	for _, s := range f.Sections {
+		report.Profiles = append(report.Profiles, s.Name)
+	}
+
+	chain, chainDiags := resolveProfileChain(f, report.ActiveProfile)
+	report.Diagnostics = append(report.Diagnostics, chainDiags...)
+	report.ProfileChain = profileNames(chain)
+
+	for _, profile := range chain {
+		for _, e := range profile.Entries {
+			if _, ok := lookupFileKey(e.Key); ok && e.Value != "" {
+				report.ProfileKeys[e.Key] = true
+			}
+		}
+	}
+
+	return report
+}
+
+// ServiceRequirement lists the credentials a service needs
+type ServiceRequirement struct {
+	Service string
+	Keys    []string // credential names, see CredentialKeys
+}
+
+// ServiceRequirements mirrors the "Required Credentials by Service" table in the README
+var ServiceRequirements = []ServiceRequirement{
+	{Service: "RDS MySQL", Keys: []string{"AccessKeyID", "SecretAccessKey", "MySQLAppKey"}},
+	{Service: "RDS MariaDB", Keys: []string{"AccessKeyID", "SecretAccessKey", "MariaDBAppKey"}},
+	{Service: "RDS PostgreSQL", Keys: []string{"AccessKeyID", "SecretAccessKey", "PostgreSQLAppKey"}},
+	{Service: "Compute/Network", Keys: []string{"Username", "Password", "TenantID"}},
+}
+
+// MissingCredentials returns the credentials of req that are not configured
+func (c *Config) MissingCredentials(req ServiceRequirement) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var missing []string
+	for _, name := range req.Keys {
+		if f := c.field(name); f == nil || *f == "" {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MinBackupRetentionEnvVar sets the backup retention, in days, below
+	// which the backup retention report flags an instance
+	MinBackupRetentionEnvVar = "NHN_CLOUD_MIN_BACKUP_RETENTION_DAYS"
+	// DefaultMinBackupRetentionDays applies when MinBackupRetentionEnvVar is unset
+	DefaultMinBackupRetentionDays = 7
+)
+
+// MinBackupRetentionDays returns the configured minimum backup retention
+func MinBackupRetentionDays() (int, error) {
+	v := strings.TrimSpace(os.Getenv(MinBackupRetentionEnvVar))
+	if v == "" {
+		return DefaultMinBackupRetentionDays, nil
+	}
+	days, err := strconv.Atoi(v)
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("%s=%q is not a number of days", MinBackupRetentionEnvVar, v)
+	}
+	return days, nil
+}
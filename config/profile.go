@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// DefaultProfile is loaded unless NHN_CLOUD_PROFILE selects another one
+	DefaultProfile = "default"
+	// ProfileEnvVar selects the credentials file profile to load
+	ProfileEnvVar = "NHN_CLOUD_PROFILE"
+	// sourceProfileKey names the profile a profile inherits unspecified keys from
+	sourceProfileKey = "source_profile"
+)
+
+// activeProfile returns the profile selected by NHN_CLOUD_PROFILE, or "default"
+func activeProfile() string {
+	if p := strings.TrimSpace(os.Getenv(ProfileEnvVar)); p != "" {
+		return p
+	}
+	return DefaultProfile
+}
+
+// resolveProfileChain follows source_profile links starting at name.
+// The returned chain starts with the named profile; each following section is
+// the parent of the previous one. Missing parents and cycles are reported as
+// errors and end the chain at the last valid profile.
+func resolveProfileChain(f *iniFile, name string) ([]*iniSection, []Diagnostic) {
+	var chain []*iniSection
+	var diags []Diagnostic
+
+	s := f.Section(name)
+	if s == nil {
+		return nil, []Diagnostic{{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("profile [%s] not found", name),
+		}}
+	}
+
+	visited := map[string]bool{}
+	for s != nil {
+		visited[s.Name] = true
+		chain = append(chain, s)
+
+		e := s.entry(sourceProfileKey)
+		if e == nil || e.Value == "" {
+			break
+		}
+
+		if visited[e.Value] {
+			names := make([]string, 0, len(chain)+1)
+			for _, c := range chain {
+				names = append(names, c.Name)
+			}
+			names = append(names, e.Value)
+			diags = append(diags, Diagnostic{
+				Line:     e.Line,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("source_profile cycle: %s; inheritance stops at [%s]", strings.Join(names, " -> "), s.Name),
+			})
+			break
+		}
+
+		parent := f.Section(e.Value)
+		if parent == nil {
+			diags = append(diags, Diagnostic{
+				Line:     e.Line,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("source_profile [%s] of [%s] not found", e.Value, s.Name),
+			})
+			break
+		}
+		s = parent
+	}
+
+	return chain, diags
+}
+
+// profileNames returns the names of a resolved chain
+func profileNames(chain []*iniSection) []string {
+	names := make([]string, 0, len(chain))
+	for _, s := range chain {
+		names = append(names, s.Name)
+	}
+	return names
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func mustParseINI(t *testing.T, text string) *iniFile {
+	t.Helper()
+	f, diags, err := parseINI(strings.NewReader(text))
+	if err != nil || len(diags) > 0 {
+		t.Fatalf("parseINI: %v %v", err, diags)
+	}
+	return f
+}
+
+func TestResolveProfileChain(t *testing.T) {
+	f := mustParseINI(t, `
+[a]
+source_profile = b
+[b]
+source_profile = a
+[c]
+source_profile = d
+[d]
+source_profile = missing
+[e]
+region = kr1
+`)
+
+	tests := []struct {
+		profile  string
+		want     []string
+		diagLine int
+		diagText string
+	}{
+		{profile: "a", want: []string{"a", "b"}, diagLine: 5, diagText: "cycle: a -> b -> a"},
+		{profile: "c", want: []string{"c", "d"}, diagLine: 9, diagText: "source_profile [missing] of [d] not found"},
+		{profile: "e", want: []string{"e"}},
+		{profile: "nope", want: []string{}, diagText: "profile [nope] not found"},
+	}
+	for _, tt := range tests {
+		chain, diags := resolveProfileChain(f, tt.profile)
+		if got := profileNames(chain); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: chain = %v, want %v", tt.profile, got, tt.want)
+		}
+		if tt.diagText == "" {
+			if len(diags) != 0 {
+				t.Errorf("%s: unexpected diagnostics %v", tt.profile, diags)
+			}
+			continue
+		}
+		if len(diags) != 1 || diags[0].Severity != SeverityError || diags[0].Line != tt.diagLine || !strings.Contains(diags[0].Message, tt.diagText) {
+			t.Errorf("%s: diagnostics = %v, want one error on line %d containing %q", tt.profile, diags, tt.diagLine, tt.diagText)
+		}
+	}
+}
+
+func TestLoadNearestProfileWins(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(ProfileEnvVar, "prod")
+	for _, k := range credentialKeys {
+		t.Setenv(k.EnvVar, "")
+	}
+
+	dir := filepath.Join(home, ".nhncloud")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	err := os.WriteFile(filepath.Join(dir, "credentials"), []byte(`
+[base]
+region = kr1
+access_key_id = base-key
+rds_app_key = base-appkey
+
+[team]
+source_profile = base
+region = kr2
+rds_app_key = team-appkey
+
+[prod]
+source_profile = team
+rds_app_key = prod-appkey
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Load()
+	for name, want := range map[string]string{
+		"Region":      "kr2",         // from the parent, overriding the grandparent
+		"AccessKeyID": "base-key",    // only set in the grandparent
+		"MySQLAppKey": "prod-appkey", // the profile itself wins
+	} {
+		if got := *cfg.field(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if got := cfg.profileOf["Region"]; got != "team" {
+		t.Errorf("Region came from [%s], want [team]", got)
+	}
+	if !slices.Equal(cfg.profileChain, []string{"prod", "team", "base"}) {
+		t.Errorf("profile chain = %v", cfg.profileChain)
+	}
+}
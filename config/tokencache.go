@@ -0,0 +1,510 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// IdentityURL is the NHN Cloud Identity endpoint used by the SDK
+	IdentityURL = "https://api-identity-infrastructure.nhncloudservice.com"
+
+	identityTokenPath = "/v2.0/tokens"
+
+	// identityRefreshWindow is how long before expiry a cached token is
+	// replaced. It is wider than the SDK's own 5 minute buffer so that the
+	// SDK never sees a token it would consider stale.
+	identityRefreshWindow = 15 * time.Minute
+
+	// tokenCacheDisableEnvVar turns the on-disk cache off when set to a non-empty value
+	tokenCacheDisableEnvVar = "NHN_CLOUD_DISABLE_TOKEN_CACHE"
+)
+
+// cachedIdentityToken is one Identity token response stored on disk
+type cachedIdentityToken struct {
+	TenantID string `json:"tenant_id"`
+	Username string `json:"username"`
+	Region   string `json:"region"`
+	// PasswordHash ties the entry to the password it was issued for, so a
+	// changed or wrong password is never answered from the cache
+	PasswordHash string          `json:"password_hash"`
+	Token        string          `json:"token"`
+	ExpiresAt    time.Time       `json:"expires_at"`
+	Response     json.RawMessage `json:"response"` // raw Identity response, including the service catalog
+}
+
+// identityTokenRequest mirrors the Identity v2.0 token request body
+type identityTokenRequest struct {
+	Auth struct {
+		TenantID            string `json:"tenantId"`
+		PasswordCredentials struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"passwordCredentials"`
+	} `json:"auth"`
+}
+
+// identityTokenResponse is the part of the Identity response the cache needs
+type identityTokenResponse struct {
+	Access struct {
+		Token struct {
+			ID      string    `json:"id"`
+			Expires time.Time `json:"expires"`
+		} `json:"token"`
+	} `json:"access"`
+}
+
+// IdentityTokenCache persists Identity tokens in ~/.nhncloud/cache/identity,
+// one 0600 file per tenant/user/password/region.
+//
+// The SDK creates a fresh Identity token provider for every client, so the
+// cache is installed as an http.RoundTripper: token requests are answered
+// from disk while the cached token is outside the refresh window, and
+// forwarded (then stored) otherwise.
+type IdentityTokenCache struct {
+	dir    string
+	region func() string
+	base   http.RoundTripper
+	now    func() time.Time
+
+	mu sync.Mutex
+}
+
+// NewIdentityTokenCache creates a cache stored in dir. region reports the
+// region to key entries by, since the token request itself carries none.
+func NewIdentityTokenCache(dir string, region func() string, base http.RoundTripper) *IdentityTokenCache {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &IdentityTokenCache{
+		dir:    dir,
+		region: region,
+		base:   base,
+		now:    time.Now,
+	}
+}
+
+// IdentityTokenCacheDir returns the default cache directory
+func IdentityTokenCacheDir() string {
+	return filepath.Join(filepath.Dir(CredentialsPath()), "cache", "identity")
+}
+
+// RoundTrip implements http.RoundTripper
+func (c *IdentityTokenCache) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isIdentityTokenRequest(req) {
+		resp, err := c.base.RoundTrip(req)
+		if err == nil && resp.StatusCode == http.StatusUnauthorized {
+			if token := req.Header.Get("X-Auth-Token"); token != "" {
+				c.invalidate(token)
+			}
+		}
+		return resp, err
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var creds identityTokenRequest
+	if err := json.Unmarshal(body, &creds); err != nil {
+		return c.forward(req, body, nil)
+	}
+	tenantID := creds.Auth.TenantID
+	username := creds.Auth.PasswordCredentials.Username
+	password := creds.Auth.PasswordCredentials.Password
+	region := c.region()
+
+	if entry, ok := c.Lookup(tenantID, username, password, region); ok && c.fresh(entry) {
+		return cachedResponse(req, entry.Response), nil
+	}
+
+	return c.forward(req, body, &cachedIdentityToken{TenantID: tenantID, Username: username, PasswordHash: identityPasswordHash(tenantID, username, password), Region: region})
+}
+
+// forward sends the token request upstream and stores a successful response
+func (c *IdentityTokenCache) forward(req *http.Request, body []byte, key *cachedIdentityToken) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+
+	resp, err := c.base.RoundTrip(out)
+	if err != nil || resp.StatusCode != http.StatusOK || key == nil {
+		return resp, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	if err := c.store(key, respBody); err != nil {
+		log.Printf("Identity token cache: %v", err)
+	}
+	return resp, nil
+}
+
+// Refresh requests a new token directly and stores it
+func (c *IdentityTokenCache) Refresh(ctx context.Context, tenantID, username, password string) (time.Time, error) {
+	req, data, err := newIdentityTokenRequest(ctx, tenantID, username, password)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	key := &cachedIdentityToken{TenantID: tenantID, Username: username, PasswordHash: identityPasswordHash(tenantID, username, password), Region: c.region()}
+	resp, err := c.forward(req, data, key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return time.Time{}, fmt.Errorf("identity token request failed with status %d", resp.StatusCode)
+	}
+
+	entry, ok := c.Lookup(tenantID, username, password, key.Region)
+	if !ok {
+		return time.Time{}, fmt.Errorf("identity token was not cached")
+	}
+	return entry.ExpiresAt, nil
+}
+
+// newIdentityTokenRequest builds an Identity v2.0 token request. The encoded
+// body is returned as well for callers that need to resend it.
+func newIdentityTokenRequest(ctx context.Context, tenantID, username, password string) (*http.Request, []byte, error) {
+	var body identityTokenRequest
+	body.Auth.TenantID = tenantID
+	body.Auth.PasswordCredentials.Username = username
+	body.Auth.PasswordCredentials.Password = password
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, IdentityURL+identityTokenPath, bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	return req, data, nil
+}
+
+// Lookup returns the cached token for tenant/user/password/region, if any
+// and unexpired
+func (c *IdentityTokenCache) Lookup(tenantID, username, password, region string) (cachedIdentityToken, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	passwordHash := identityPasswordHash(tenantID, username, password)
+	data, err := os.ReadFile(c.path(tenantID, username, passwordHash, region))
+	if err != nil {
+		return cachedIdentityToken{}, false
+	}
+
+	var entry cachedIdentityToken
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return cachedIdentityToken{}, false
+	}
+	if entry.TenantID != tenantID || entry.Username != username || entry.PasswordHash != passwordHash || entry.Region != region {
+		return cachedIdentityToken{}, false
+	}
+	if !c.now().Before(entry.ExpiresAt) {
+		return cachedIdentityToken{}, false
+	}
+	return entry, true
+}
+
+// fresh reports whether entry is outside the refresh window
+func (c *IdentityTokenCache) fresh(entry cachedIdentityToken) bool {
+	return c.now().Add(identityRefreshWindow).Before(entry.ExpiresAt)
+}
+
+func (c *IdentityTokenCache) store(key *cachedIdentityToken, respBody []byte) error {
+	var parsed identityTokenResponse
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return fmt.Errorf("parsing token response: %w", err)
+	}
+	if parsed.Access.Token.ID == "" || parsed.Access.Token.Expires.IsZero() {
+		return fmt.Errorf("token response has no token or expiry")
+	}
+
+	entry := *key
+	entry.Token = parsed.Access.Token.ID
+	entry.ExpiresAt = parsed.Access.Token.Expires
+	entry.Response = respBody
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".token-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.path(entry.TenantID, entry.Username, entry.PasswordHash, entry.Region)); err != nil {
+		return err
+	}
+	c.prune(entry)
+	return nil
+}
+
+// prune removes expired entries and entries for the same tenant, user and
+// region as current that were issued for another password. Callers hold c.mu.
+func (c *IdentityTokenCache) prune(current cachedIdentityToken) {
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return
+	}
+	now := c.now()
+	for _, name := range files {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		var entry cachedIdentityToken
+		if json.Unmarshal(data, &entry) != nil {
+			continue
+		}
+		stale := entry.TenantID == current.TenantID && entry.Username == current.Username &&
+			entry.Region == current.Region && entry.PasswordHash != current.PasswordHash
+		if stale || !now.Before(entry.ExpiresAt) {
+			os.Remove(name)
+		}
+	}
+}
+
+// invalidate removes any cache entry holding token, e.g. after it was rejected
+func (c *IdentityTokenCache) invalidate(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return
+	}
+	for _, name := range files {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		var entry cachedIdentityToken
+		if json.Unmarshal(data, &entry) == nil && entry.Token == token {
+			os.Remove(name)
+		}
+	}
+}
+
+func (c *IdentityTokenCache) path(tenantID, username, passwordHash, region string) string {
+	sum := sha256.Sum256([]byte(tenantID + "\x00" + username + "\x00" + passwordHash + "\x00" + region))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+// identityPasswordHash identifies a password without storing it
+func identityPasswordHash(tenantID, username, password string) string {
+	sum := sha256.Sum256([]byte("identity-token-cache\x00" + tenantID + "\x00" + username + "\x00" + password))
+	return hex.EncodeToString(sum[:])
+}
+
+func isIdentityTokenRequest(req *http.Request) bool {
+	return req.Method == http.MethodPost &&
+		req.URL.Scheme+"://"+req.URL.Host == IdentityURL &&
+		strings.TrimSuffix(req.URL.Path, "/") == identityTokenPath
+}
+
+func cachedResponse(req *http.Request, body []byte) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// identityRoute sends Identity token requests, and requests authenticated
+// with an Identity token, to the cache and everything else straight to base
+type identityRoute struct {
+	cache *IdentityTokenCache
+	base  http.RoundTripper
+}
+
+func (r identityRoute) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isIdentityTokenRequest(req) || req.Header.Get("X-Auth-Token") != "" {
+		return r.cache.RoundTrip(req)
+	}
+	return r.base.RoundTrip(req)
+}
+
+// EnableIdentityTokenCache routes Identity token requests through an on-disk
+// cache. Set NHN_CLOUD_DISABLE_TOKEN_CACHE to turn it off.
+//
+// Identity tokens requested here use the cache directly through
+// IdentityHTTPClient. The SDK's Identity token providers build their own
+// http.Client on http.DefaultTransport and take no transport, so that is
+// wrapped as well, but only for token requests and requests carrying an
+// Identity token (to drop tokens rejected with 401); all other requests go
+// to the original transport untouched.
+func (c *Config) EnableIdentityTokenCache() {
+	if os.Getenv(tokenCacheDisableEnvVar) != "" {
+		return
+	}
+
+	region := func() string {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		return c.Region
+	}
+	base := http.DefaultTransport
+	cache := NewIdentityTokenCache(IdentityTokenCacheDir(), region, base)
+
+	c.mu.Lock()
+	c.tokenCache = cache
+	c.mu.Unlock()
+
+	http.DefaultTransport = identityRoute{cache: cache, base: base}
+}
+
+// IdentityHTTPClient returns the client to request Identity tokens with. It
+// answers from the on-disk cache when that is enabled.
+func (c *Config) IdentityHTTPClient() *http.Client {
+	c.mu.RLock()
+	cache := c.tokenCache
+	c.mu.RUnlock()
+
+	if cache == nil {
+		return &http.Client{Timeout: 30 * time.Second}
+	}
+	return &http.Client{Transport: cache, Timeout: 30 * time.Second}
+}
+
+// IdentityTokenExpiry returns when the cached Identity token for the current
+// Compute credentials expires. ok is false if no valid token is cached.
+func (c *Config) IdentityTokenExpiry() (expiresAt time.Time, ok bool) {
+	c.mu.RLock()
+	cache, tenantID, username, password, region := c.tokenCache, c.TenantID, c.Username, c.Password, c.Region
+	c.mu.RUnlock()
+
+	if cache == nil || tenantID == "" || username == "" {
+		return time.Time{}, false
+	}
+	entry, ok := cache.Lookup(tenantID, username, password, region)
+	if !ok {
+		return time.Time{}, false
+	}
+	return entry.ExpiresAt, true
+}
+
+// StartIdentityTokenRefresher refreshes the cached Identity token for the
+// current Compute credentials before it enters the refresh window, until ctx
+// is done. Tokens are only refreshed once one has been cached by a real
+// request, so idle servers do not authenticate on their own.
+func (c *Config) StartIdentityTokenRefresher(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			c.mu.RLock()
+			cache, tenantID, username, password, region := c.tokenCache, c.TenantID, c.Username, c.Password, c.Region
+			c.mu.RUnlock()
+
+			if cache == nil || tenantID == "" || username == "" || password == "" {
+				continue
+			}
+			entry, ok := cache.Lookup(tenantID, username, password, region)
+			if !ok || cache.now().Add(identityRefreshWindow+interval).Before(entry.ExpiresAt) {
+				continue
+			}
+
+			reqCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+			if _, err := cache.Refresh(reqCtx, tenantID, username, password); err != nil {
+				log.Printf("Identity token refresh failed: %v", err)
+			}
+			cancel()
+		}
+	}()
+}
+
+// IdentityToken returns an Identity token for the API user in tenantID. The
+// on-disk cache answers the request when enabled.
+func (c *Config) IdentityToken(ctx context.Context, tenantID string) (string, error) {
+	c.mu.RLock()
+	username, password := c.Username, c.Password
+	c.mu.RUnlock()
+
+	if tenantID == "" || username == "" || password == "" {
+		return "", fmt.Errorf("identity credentials are not configured (need tenant ID, username and API password)")
+	}
+
+	req, _, err := newIdentityTokenRequest(ctx, tenantID, username, password)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.IdentityHTTPClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("identity token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("identity token request failed with status %d", resp.StatusCode)
+	}
+
+	var parsed identityTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("parsing token response: %w", err)
+	}
+	if parsed.Access.Token.ID == "" {
+		return "", fmt.Errorf("token response has no token")
+	}
+	return parsed.Access.Token.ID, nil
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeIdentity answers token requests with a new token per call and other
+// requests with status
+type fakeIdentity struct {
+	expires time.Time
+	status  int
+	calls   int
+}
+
+func (f *fakeIdentity) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.calls++
+	body := "{}"
+	status := f.status
+	if isIdentityTokenRequest(req) {
+		body = fmt.Sprintf(`{"access":{"token":{"id":"token-%d","expires":%q}}}`, f.calls, f.expires.Format(time.RFC3339))
+		status = http.StatusOK
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func newTestTokenCache(t *testing.T, now time.Time) (*IdentityTokenCache, *fakeIdentity) {
+	t.Helper()
+	upstream := &fakeIdentity{expires: now.Add(time.Hour), status: http.StatusOK}
+	cache := NewIdentityTokenCache(t.TempDir(), func() string { return "KR1" }, upstream)
+	cache.now = func() time.Time { return now }
+	return cache, upstream
+}
+
+// requestToken sends a token request through cache and returns the token
+func requestToken(t *testing.T, cache *IdentityTokenCache, password string) string {
+	t.Helper()
+	var body identityTokenRequest
+	body.Auth.TenantID = "tenant"
+	body.Auth.PasswordCredentials.Username = "user@example.com"
+	body.Auth.PasswordCredentials.Password = password
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, IdentityURL+identityTokenPath, bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := cache.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var parsed identityTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		t.Fatal(err)
+	}
+	return parsed.Access.Token.ID
+}
+
+func TestIdentityTokenCacheHit(t *testing.T) {
+	cache, upstream := newTestTokenCache(t, time.Now())
+
+	first := requestToken(t, cache, "pw")
+	second := requestToken(t, cache, "pw")
+	if first != second || upstream.calls != 1 {
+		t.Errorf("got tokens %q and %q after %d upstream calls, want one cached token", first, second, upstream.calls)
+	}
+}
+
+func TestIdentityTokenCacheKeyedByPassword(t *testing.T) {
+	cache, upstream := newTestTokenCache(t, time.Now())
+
+	requestToken(t, cache, "old-password")
+	requestToken(t, cache, "new-password")
+	if upstream.calls != 2 {
+		t.Errorf("upstream calls = %d, want 2: a different password must not be answered from the cache", upstream.calls)
+	}
+	if _, ok := cache.Lookup("tenant", "user@example.com", "wrong-password", "KR1"); ok {
+		t.Error("Lookup found a token for a password that was never used")
+	}
+}
+
+func TestIdentityTokenCacheExpiry(t *testing.T) {
+	now := time.Now()
+	cache, upstream := newTestTokenCache(t, now)
+
+	requestToken(t, cache, "pw")
+	cache.now = func() time.Time { return now.Add(2 * time.Hour) }
+	if _, ok := cache.Lookup("tenant", "user@example.com", "pw", "KR1"); ok {
+		t.Error("Lookup returned an expired token")
+	}
+	requestToken(t, cache, "pw")
+	if upstream.calls != 2 {
+		t.Errorf("upstream calls = %d, want 2 after expiry", upstream.calls)
+	}
+}
+
+func TestIdentityTokenCacheRefreshWindow(t *testing.T) {
+	now := time.Now()
+	cache, upstream := newTestTokenCache(t, now)
+
+	requestToken(t, cache, "pw")
+	// Still valid, but inside the refresh window
+	cache.now = func() time.Time { return now.Add(time.Hour - identityRefreshWindow + time.Minute) }
+	if _, ok := cache.Lookup("tenant", "user@example.com", "pw", "KR1"); !ok {
+		t.Fatal("Lookup lost a token that has not expired")
+	}
+	if got := requestToken(t, cache, "pw"); got != "token-2" || upstream.calls != 2 {
+		t.Errorf("got %q after %d upstream calls, want a new token inside the refresh window", got, upstream.calls)
+	}
+}
+
+func TestIdentityTokenCacheInvalidatedOn401(t *testing.T) {
+	cache, upstream := newTestTokenCache(t, time.Now())
+
+	token := requestToken(t, cache, "pw")
+	upstream.status = http.StatusUnauthorized
+	req, err := http.NewRequest(http.MethodGet, "https://kr1-api-instance-infrastructure.nhncloudservice.com/v2/tenant/servers", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Auth-Token", token)
+	resp, err := cache.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if _, ok := cache.Lookup("tenant", "user@example.com", "pw", "KR1"); ok {
+		t.Error("token rejected with 401 is still cached")
+	}
+}
+
+func TestIdentityTokenCachePrunesOldPasswords(t *testing.T) {
+	cache, _ := newTestTokenCache(t, time.Now())
+
+	requestToken(t, cache, "old-password")
+	requestToken(t, cache, "new-password")
+	files, err := filepath.Glob(filepath.Join(cache.dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("cache holds %d entries, want only the one for the new password", len(files))
+	}
+	if _, ok := cache.Lookup("tenant", "user@example.com", "new-password", "KR1"); !ok {
+		t.Error("entry for the new password was pruned")
+	}
+}
+
+func TestIdentityRouteOnlyDivertsIdentityTraffic(t *testing.T) {
+	cache, upstream := newTestTokenCache(t, time.Now())
+	other := &fakeIdentity{status: http.StatusUnauthorized}
+	route := identityRoute{cache: cache, base: other}
+
+	req, err := http.NewRequest(http.MethodGet, "https://kr1-api-rds-mysql.nhncloudservice.com/v3.0/db-instances", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := route.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if other.calls != 1 || upstream.calls != 0 {
+		t.Errorf("plain request reached base %d times and the cache upstream %d times, want 1 and 0", other.calls, upstream.calls)
+	}
+}
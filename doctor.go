@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+)
+
+// doctorStatus is the outcome of a single doctor check
+type doctorStatus string
+
+const (
+	doctorOK   doctorStatus = "ok"
+	doctorWarn doctorStatus = "warn"
+	doctorFail doctorStatus = "fail"
+	doctorSkip doctorStatus = "skip"
+)
+
+// doctorCheck is one line of the doctor report, with an optional fix-it hint
+type doctorCheck struct {
+	Status  doctorStatus
+	Message string
+	Fix     string
+}
+
+// doctorSection groups related checks under a heading
+type doctorSection struct {
+	Title  string
+	Checks []doctorCheck
+}
+
+func (s *doctorSection) add(status doctorStatus, message, fix string) {
+	s.Checks = append(s.Checks, doctorCheck{Status: status, Message: message, Fix: fix})
+}
+
+// runDoctor implements `nhn-cloud-mcp doctor` and returns the process exit code
+func runDoctor(args []string, stdout io.Writer) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+	live := fs.Bool("live", false, "perform live authentication checks against NHN Cloud APIs")
+	timeout := fs.Duration("timeout", 20*time.Second, "timeout for each live check")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg := config.Load()
+	cfg.EnableIdentityTokenCache()
+
+	report := config.InspectCredentialsFile()
+	sections := []*doctorSection{
+		checkCredentialsFile(report),
+		checkEnvOverrides(report),
+		checkServiceCredentials(cfg),
+	}
+	if *live {
+		sections = append(sections, checkLiveAuth(cfg, *timeout))
+	}
+
+	return printDoctorReport(stdout, sections)
+}
+
+func checkCredentialsFile(report *config.FileReport) *doctorSection {
+	s := &doctorSection{Title: "Credentials file (" + report.Path + ")"}
+
+	if !report.Exists {
+		s.add(doctorWarn, "file does not exist",
+			fmt.Sprintf("create %s with a [default] profile (see README) or use environment variables", report.Path))
+		return s
+	}
+	s.add(doctorOK, "file exists", "")
+
+	if report.ReadError != nil {
+		s.add(doctorFail, fmt.Sprintf("file cannot be read: %v", report.ReadError),
+			fmt.Sprintf("check ownership of %s", report.Path))
+		return s
+	}
+
+	if report.Mode&^config.RecommendedFileMode != 0 {
+		s.add(doctorWarn, fmt.Sprintf("file permissions are %04o, recommended %04o", report.Mode, config.RecommendedFileMode),
+			fmt.Sprintf("chmod 600 %s", report.Path))
+	} else {
+		s.add(doctorOK, fmt.Sprintf("file permissions are %04o", report.Mode), "")
+	}
+
+	if report.DirMode&^config.RecommendedDirMode != 0 {
+		s.add(doctorWarn, fmt.Sprintf("directory permissions are %04o, recommended %04o", report.DirMode, config.RecommendedDirMode),
+			fmt.Sprintf("chmod 700 %s", report.DirPath))
+	}
+
+	for _, d := range report.Diagnostics {
+		status := doctorWarn
+		if d.Severity == config.SeverityError {
+			status = doctorFail
+		}
+		fix := fmt.Sprintf("fix line %d of %s", d.Line, report.Path)
+		if d.Line == 0 {
+			fix = fmt.Sprintf("add a [%s] section to %s or change %s", report.ActiveProfile, report.Path, config.ProfileEnvVar)
+		}
+		s.add(status, d.String(), fix)
+	}
+
+	if len(report.ProfileChain) > 0 {
+		s.add(doctorOK, "using profile "+strings.Join(report.ProfileChain, " -> "), "")
+	}
+
+	if ignored := report.IgnoredProfiles(); len(ignored) > 0 {
+		s.add(doctorWarn, fmt.Sprintf("profiles not used by [%s]: %s", report.ActiveProfile, strings.Join(ignored, ", ")),
+			fmt.Sprintf("set %s to select another profile, or use source_profile to inherit from one", config.ProfileEnvVar))
+	}
+
+	return s
+}
+
+func checkEnvOverrides(report *config.FileReport) *doctorSection {
+	s := &doctorSection{Title: "Environment variables"}
+	fileKeys := report.ProfileKeys
+
+	for _, k := range config.CredentialKeys() {
+		if os.Getenv(k.EnvVar) == "" {
+			continue
+		}
+		if fileKeys[k.FileKey] {
+			s.add(doctorWarn, fmt.Sprintf("%s overrides %s from the credentials file", k.EnvVar, k.FileKey),
+				fmt.Sprintf("unset %s if the file value should be used", k.EnvVar))
+		} else {
+			s.add(doctorOK, fmt.Sprintf("%s is set", k.EnvVar), "")
+		}
+	}
+
+	if len(s.Checks) == 0 {
+		s.add(doctorOK, "no NHN_CLOUD_* overrides set", "")
+	}
+	return s
+}
+
+func checkServiceCredentials(cfg *config.Config) *doctorSection {
+	s := &doctorSection{Title: "Credentials by service"}
+
+	for _, req := range config.ServiceRequirements {
+		missing := cfg.MissingCredentials(req)
+		if len(missing) == 0 {
+			s.add(doctorOK, req.Service+": configured", "")
+			continue
+		}
+
+		var names, fixes []string
+		for _, name := range missing {
+			k, _ := config.LookupCredential(name)
+			names = append(names, k.FileKey)
+			fixes = append(fixes, fmt.Sprintf("%s (or %s)", k.FileKey, k.EnvVar))
+		}
+		s.add(doctorWarn, fmt.Sprintf("%s: missing %s", req.Service, strings.Join(names, ", ")),
+			"set "+strings.Join(fixes, ", "))
+	}
+	return s
+}
+
+func checkLiveAuth(cfg *config.Config, timeout time.Duration) *doctorSection {
+	s := &doctorSection{Title: "Live authentication"}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		s.add(doctorFail, fmt.Sprintf("cannot create client: %v", err), "configure access_key_id and secret_access_key")
+		return s
+	}
+
+	probes := map[string]func(ctx context.Context) error{
+		"RDS MySQL": func(ctx context.Context) error {
+			_, err := client.MySQL().ListFlavors(ctx)
+			return err
+		},
+		"RDS MariaDB": func(ctx context.Context) error {
+			_, err := client.MariaDB().ListFlavors(ctx)
+			return err
+		},
+		"RDS PostgreSQL": func(ctx context.Context) error {
+			_, err := client.PostgreSQL().ListFlavors(ctx)
+			return err
+		},
+		"Compute/Network": func(ctx context.Context) error {
+			_, err := client.Compute().ListFlavors(ctx)
+			return err
+		},
+	}
+
+	for _, req := range config.ServiceRequirements {
+		call, ok := probes[req.Service]
+		if !ok {
+			continue
+		}
+		if len(cfg.MissingCredentials(req)) > 0 {
+			s.add(doctorSkip, req.Service+": credentials not configured", "")
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := call(ctx)
+		cancel()
+		if err != nil {
+			s.add(doctorFail, fmt.Sprintf("%s: %v", req.Service, err),
+				fmt.Sprintf("verify the %s credentials and region %q", req.Service, cfg.Region))
+			continue
+		}
+		s.add(doctorOK, req.Service+": authenticated", "")
+	}
+
+	if expiresAt, ok := cfg.IdentityTokenExpiry(); ok {
+		s.add(doctorOK, fmt.Sprintf("Identity token cached until %s", expiresAt.Local().Format(time.RFC3339)), "")
+	}
+
+	return s
+}
+
+// printDoctorReport writes the report and a numbered fix-it checklist.
+// It returns 1 if any check failed.
+func printDoctorReport(w io.Writer, sections []*doctorSection) int {
+	fmt.Fprintf(w, "%s doctor\n", serverName)
+
+	var fixes []string
+	failed := false
+	for _, s := range sections {
+		fmt.Fprintf(w, "\n%s\n", s.Title)
+		for _, c := range s.Checks {
+			fmt.Fprintf(w, "  [%-4s] %s\n", c.Status, c.Message)
+			if c.Status == doctorFail {
+				failed = true
+			}
+			if c.Fix != "" && (c.Status == doctorWarn || c.Status == doctorFail) {
+				fixes = append(fixes, c.Fix)
+			}
+		}
+	}
+
+	if len(fixes) == 0 {
+		fmt.Fprintln(w, "\nNo problems found.")
+	} else {
+		fmt.Fprintln(w, "\nFix-it checklist:")
+		for i, fix := range fixes {
+			fmt.Fprintf(w, "  %d. %s\n", i+1, fix)
+		}
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+)
+
+func TestDoctorReport(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(config.ProfileEnvVar, "")
+	t.Setenv("NHN_CLOUD_DISABLE_TOKEN_CACHE", "1")
+	for _, k := range config.CredentialKeys() {
+		t.Setenv(k.EnvVar, "")
+	}
+	t.Setenv("NHN_CLOUD_REGION", "kr2")
+
+	dir := filepath.Join(home, ".nhncloud")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "credentials")
+	err := os.WriteFile(path, []byte(`[default]
+region = kr1
+access_key_id = key
+secret_access_key = secret
+colour = blue
+no separator
+
+[staging]
+region = kr2
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Set the modes explicitly so the umask does not hide the warnings
+	if err := os.Chmod(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if code := runDoctor(nil, &out); code != 1 {
+		t.Errorf("exit code = %d, want 1 for a file with errors", code)
+	}
+	for _, want := range []string{
+		"[warn] file permissions are 0644, recommended 0600",
+		"[warn] directory permissions are 0755, recommended 0700",
+		`[warn] line 5: warning: unknown key "colour" in [default] is ignored`,
+		`[fail] line 6: error: expected key = value, got "no separator"`,
+		"[warn] profiles not used by [default]: staging",
+		"[warn] NHN_CLOUD_REGION overrides region from the credentials file",
+		"[warn] RDS MySQL: missing rds_app_key",
+		"1. chmod 600 " + path,
+		"2. chmod 700 " + dir,
+		"4. fix line 6 of " + path,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report is missing %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/haung921209/nhn-cloud-mcp/config"
 	"github.com/haung921209/nhn-cloud-mcp/tools"
@@ -16,13 +18,24 @@ const (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "doctor" {
+		os.Exit(runDoctor(os.Args[2:], os.Stdout))
+	}
+
 	cfg := config.Load()
+	cfg.EnableIdentityTokenCache()
+
+	ctx := context.Background()
+	cfg.StartIdentityTokenRefresher(ctx, time.Minute)
 
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    serverName,
 		Version: serverVersion,
 	}, nil)
 
+	// Mask configured secrets and secret-looking values in every tool result
+	server.AddReceivingMiddleware(tools.NewRedactor(cfg).Middleware())
+
 	tools.RegisterAuthTools(server, cfg)
 	log.Println("Registered auth tools (nhn_set_credential, nhn_get_credential_status)")
 
@@ -34,20 +47,30 @@ func main() {
 	logCredentialStatus(cfg)
 
 	log.Printf("Starting %s v%s...\n", serverName, serverVersion)
-	if err := server.Run(context.Background(), &mcp.StdioTransport{}); err != nil {
+	if err := server.Run(ctx, &mcp.StdioTransport{}); err != nil {
 		log.Printf("Server error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
 func logCredentialStatus(cfg *config.Config) {
+	if chain := cfg.ProfileChain(); len(chain) > 0 {
+		log.Printf("Credentials file profile: %s", strings.Join(chain, " -> "))
+	}
+	if n := len(cfg.FileDiagnostics()); n > 0 {
+		log.Printf("Credentials file: %d issue(s) found - run `nhn-cloud-mcp doctor` for details", n)
+	}
+
 	if cfg.HasRDSCredentials() {
 		log.Printf("RDS credentials: configured (source: %s)", cfg.GetSource("AccessKeyID"))
 	} else {
-		log.Println("RDS credentials: not configured - use nhn_set_credential tool or configure ~/.nhncloud/credentials")
+		log.Println("RDS credentials: not configured - use nhn_set_credential tool or configure ~/.nhncloud/credentials (run `nhn-cloud-mcp doctor` to diagnose)")
 	}
 
 	if cfg.HasComputeCredentials() {
 		log.Printf("Compute credentials: configured (source: %s)", cfg.GetSource("Username"))
+		if expiresAt, ok := cfg.IdentityTokenExpiry(); ok {
+			log.Printf("Identity token: cached, expires %s", expiresAt.Local().Format(time.RFC3339))
+		}
 	}
 }
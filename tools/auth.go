@@ -3,6 +3,8 @@ package tools
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/haung921209/nhn-cloud-mcp/config"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -24,12 +26,31 @@ type CredentialStatusItem struct {
 	Name       string `json:"name"`
 	Configured bool   `json:"configured"`
 	Source     string `json:"source"`
+	Profile    string `json:"profile,omitempty"`
+}
+
+// CredentialFileDiagnostic is a problem found while parsing ~/.nhncloud/credentials
+type CredentialFileDiagnostic struct {
+	Line     int    `json:"line"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
 }
 
 type GetCredentialStatusOutput struct {
-	Credentials  []CredentialStatusItem `json:"credentials"`
-	RDSReady     bool                   `json:"rds_ready"`
-	ComputeReady bool                   `json:"compute_ready"`
+	Credentials     []CredentialStatusItem     `json:"credentials"`
+	RDSReady        bool                       `json:"rds_ready"`
+	ComputeReady    bool                       `json:"compute_ready"`
+	Profile         string                     `json:"profile"`
+	ProfileChain    []string                   `json:"profile_chain,omitempty"`
+	FileDiagnostics []CredentialFileDiagnostic `json:"file_diagnostics,omitempty"`
+	IdentityToken   *IdentityTokenStatus       `json:"identity_token,omitempty"`
+}
+
+// IdentityTokenStatus describes the cached Identity token used by Compute/Network APIs
+type IdentityTokenStatus struct {
+	Cached           bool   `json:"cached"`
+	ExpiresAt        string `json:"expires_at,omitempty"`
+	ExpiresInSeconds int64  `json:"expires_in_seconds,omitempty"`
 }
 
 func RegisterAuthTools(server *mcp.Server, cfg *config.Config) {
@@ -73,7 +94,7 @@ func RegisterAuthTools(server *mcp.Server, cfg *config.Config) {
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "nhn_get_credential_status",
-		Description: "Check which NHN Cloud credentials are configured and their source (file, env, interactive, or none). Does not expose credential values.",
+		Description: "Check which NHN Cloud credentials are configured and their source (file, env, interactive, or none), the credentials file profile and its source_profile inheritance chain, the cached Identity token expiry, plus any line-numbered problems found in ~/.nhncloud/credentials. Does not expose credential values.",
 	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[GetCredentialStatusInput]) (*mcp.CallToolResultFor[GetCredentialStatusOutput], error) {
 		status := cfg.GetStatus()
 
@@ -83,6 +104,7 @@ func RegisterAuthTools(server *mcp.Server, cfg *config.Config) {
 				Name:       name,
 				Configured: info["configured"] == "yes",
 				Source:     info["source"],
+				Profile:    info["profile"],
 			})
 		}
 
@@ -90,9 +112,39 @@ func RegisterAuthTools(server *mcp.Server, cfg *config.Config) {
 			Credentials:  creds,
 			RDSReady:     cfg.HasRDSCredentials(),
 			ComputeReady: cfg.HasComputeCredentials(),
+			Profile:      cfg.Profile(),
+			ProfileChain: cfg.ProfileChain(),
+		}
+
+		for _, d := range cfg.FileDiagnostics() {
+			out.FileDiagnostics = append(out.FileDiagnostics, CredentialFileDiagnostic{
+				Line:     d.Line,
+				Severity: d.Severity,
+				Message:  d.Message,
+			})
+		}
+
+		if out.ComputeReady {
+			out.IdentityToken = &IdentityTokenStatus{}
+			if expiresAt, ok := cfg.IdentityTokenExpiry(); ok {
+				out.IdentityToken.Cached = true
+				out.IdentityToken.ExpiresAt = expiresAt.Format(time.RFC3339)
+				out.IdentityToken.ExpiresInSeconds = int64(time.Until(expiresAt).Seconds())
+			}
 		}
 
 		summary := fmt.Sprintf("RDS Ready: %v, Compute Ready: %v", out.RDSReady, out.ComputeReady)
+		if len(out.ProfileChain) > 1 {
+			summary += fmt.Sprintf(", profile: %s", strings.Join(out.ProfileChain, " -> "))
+		} else {
+			summary += fmt.Sprintf(", profile: %s", out.Profile)
+		}
+		if n := len(out.FileDiagnostics); n > 0 {
+			summary += fmt.Sprintf(" (%d credentials file issue(s), see file_diagnostics)", n)
+		}
+		if out.IdentityToken != nil && out.IdentityToken.Cached {
+			summary += fmt.Sprintf(", identity token expires %s", out.IdentityToken.ExpiresAt)
+		}
 		return &mcp.CallToolResultFor[GetCredentialStatusOutput]{
 			Content:           []mcp.Content{&mcp.TextContent{Text: summary}},
 			StructuredContent: out,
@@ -3,8 +3,12 @@ package tools
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/rds/mysql"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -21,6 +25,37 @@ type MySQLInstance struct {
 	Version     string `json:"version"`
 	StorageType string `json:"storage_type"`
 	StorageSize int    `json:"storage_size_gb"`
+	Role        string `json:"role"`
+	GroupID     string `json:"group_id"`
+}
+
+// Instance roles within a replication group, as reported in dbInstanceType
+const (
+	mysqlRoleMaster          = "MASTER"
+	mysqlRoleCandidateMaster = "CANDIDATE_MASTER"
+	mysqlRoleReplica         = "READ_ONLY_SLAVE"
+	mysqlRoleFailedMaster    = "FAILED_MASTER"
+)
+
+// mysqlInstanceRecord adds the replication fields the SDK does not decode.
+// A master, its HA candidate and its read replicas share one dbInstanceGroupId.
+type mysqlInstanceRecord struct {
+	mysql.DatabaseInstance
+	DBInstanceType    string `json:"dbInstanceType"`
+	DBInstanceGroupID string `json:"dbInstanceGroupId"`
+}
+
+func newMySQLInstance(rec mysqlInstanceRecord) MySQLInstance {
+	return MySQLInstance{
+		ID:          rec.DBInstanceID,
+		Name:        rec.DBInstanceName,
+		Status:      rec.DBInstanceStatus,
+		Version:     rec.DBVersion,
+		StorageType: rec.StorageType,
+		StorageSize: rec.StorageSize,
+		Role:        rec.DBInstanceType,
+		GroupID:     rec.DBInstanceGroupID,
+	}
 }
 
 // ListMySQLInstancesOutput - output for listing MySQL instances
@@ -57,21 +92,41 @@ type ListMySQLFlavorsOutput struct {
 
 type ListMySQLBackupsInput struct {
 	InstanceID string `json:"instance_id,omitempty" jsonschema_description:"Filter by instance ID (optional)"`
+	BackupType string `json:"backup_type,omitempty" jsonschema_description:"Filter by backup type: auto or manual (optional)"`
+	From       string `json:"from,omitempty" jsonschema_description:"Only backups created at or after this RFC 3339 time (optional, applied to the pages read)"`
+	To         string `json:"to,omitempty" jsonschema_description:"Only backups created before this RFC 3339 time (optional, applied to the pages read)"`
+	Page       int    `json:"page,omitempty" jsonschema_description:"Page to read, starting at 1 (default 1)"`
+	Size       int    `json:"size,omitempty" jsonschema_description:"Backups per page (default and max 100)"`
+	AllPages   bool   `json:"all_pages,omitempty" jsonschema_description:"Keep reading pages from page until the list ends or max_pages is reached"`
+	MaxPages   int    `json:"max_pages,omitempty" jsonschema_description:"Most pages to read with all_pages (default and max 20)"`
 }
 
 // MySQLBackup represents a MySQL backup
 type MySQLBackup struct {
 	ID         string `json:"id"`
+	Name       string `json:"name,omitempty"`
 	InstanceID string `json:"instance_id"`
+	Type       string `json:"type,omitempty"`
 	Status     string `json:"status"`
+	Version    string `json:"version,omitempty"`
 	Size       int64  `json:"size_gb"`
 	CreatedAt  string `json:"created_at"`
 }
 
-// ListMySQLBackupsOutput - output for listing MySQL backups
+// ListMySQLBackupsOutput - output for listing MySQL backups. Total and
+// NextPage count backups matching instance and type only; the date range is
+// applied to the Scanned backups of the pages read.
 type ListMySQLBackupsOutput struct {
-	Backups []MySQLBackup `json:"backups"`
-	Count   int           `json:"count"`
+	Backups   []MySQLBackup `json:"backups"`
+	Count     int           `json:"count"`
+	Scanned   int           `json:"scanned"`
+	Total     int           `json:"total_unfiltered_by_date"`
+	Undated   []string      `json:"undated_backup_ids,omitempty"`
+	Page      int           `json:"page"`
+	Size      int           `json:"size"`
+	PagesRead int           `json:"pages_read"`
+	NextPage  int           `json:"next_page,omitempty"`
+	Truncated bool          `json:"truncated"`
 }
 
 // RegisterMySQLTools registers all MySQL-related tools to the MCP server
@@ -79,7 +134,7 @@ func RegisterMySQLTools(server *mcp.Server, cfg *config.Config) {
 	// List MySQL Instances
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "nhn_mysql_list_instances",
-		Description: "List all NHN Cloud RDS MySQL instances. Returns instance ID, name, status, version, storage type, and storage size.",
+		Description: "List all NHN Cloud RDS MySQL instances. Returns instance ID, name, status, version, storage type, storage size, and replication role (MASTER, CANDIDATE_MASTER, READ_ONLY_SLAVE) with the replication group ID.",
 	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLInstancesInput]) (*mcp.CallToolResultFor[ListMySQLInstancesOutput], error) {
 		out, err := listMySQLInstances(ctx, cfg)
 		if err != nil {
@@ -97,17 +152,17 @@ func RegisterMySQLTools(server *mcp.Server, cfg *config.Config) {
 	// Get MySQL Instance
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "nhn_mysql_get_instance",
-		Description: "Get details of a specific NHN Cloud RDS MySQL instance by ID.",
-	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[GetMySQLInstanceInput]) (*mcp.CallToolResultFor[GetMySQLInstanceOutput], error) {
-		out, err := getMySQLInstance(ctx, cfg, params.Arguments.InstanceID)
+		Description: "Get full details of an NHN Cloud RDS MySQL instance by ID: flavor (name, vCPUs, RAM), replication role, high availability state, network endpoints and port, parameter and security groups, automatic backup settings, deletion protection and timestamps. Use nhn_mysql_list_instances for a compact overview.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[GetMySQLInstanceInput]) (*mcp.CallToolResultFor[GetMySQLInstanceDetailOutput], error) {
+		out, err := getMySQLInstanceDetail(ctx, cfg, params.Arguments.InstanceID)
 		if err != nil {
-			return &mcp.CallToolResultFor[GetMySQLInstanceOutput]{
+			return &mcp.CallToolResultFor[GetMySQLInstanceDetailOutput]{
 				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
 				IsError: true,
 			}, nil
 		}
-		return &mcp.CallToolResultFor[GetMySQLInstanceOutput]{
-			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Instance: %s (%s)", out.Instance.Name, out.Instance.Status)}},
+		return &mcp.CallToolResultFor[GetMySQLInstanceDetailOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: formatMySQLInstanceDetail(out.Instance)}},
 			StructuredContent: out,
 		}, nil
 	})
@@ -133,9 +188,9 @@ func RegisterMySQLTools(server *mcp.Server, cfg *config.Config) {
 	// List MySQL Backups
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "nhn_mysql_list_backups",
-		Description: "List NHN Cloud RDS MySQL backups. Optionally filter by instance ID.",
+		Description: "List NHN Cloud RDS MySQL backups one page at a time, or walk several pages with all_pages. Filter by instance, backup type and creation time. When truncated is true, call again with page set to next_page.",
 	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLBackupsInput]) (*mcp.CallToolResultFor[ListMySQLBackupsOutput], error) {
-		out, err := listMySQLBackups(ctx, cfg, params.Arguments.InstanceID)
+		out, err := listMySQLBackups(ctx, cfg, params.Arguments)
 		if err != nil {
 			return &mcp.CallToolResultFor[ListMySQLBackupsOutput]{
 				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
@@ -143,35 +198,48 @@ func RegisterMySQLTools(server *mcp.Server, cfg *config.Config) {
 			}, nil
 		}
 		return &mcp.CallToolResultFor[ListMySQLBackupsOutput]{
-			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Found %d MySQL backups", out.Count)}},
+			Content:           []mcp.Content{&mcp.TextContent{Text: formatMySQLBackupList(out)}},
 			StructuredContent: out,
 		}, nil
 	})
+
+	registerMySQLCreateTools(server, cfg)
+	registerMySQLLifecycleTools(server, cfg)
+	registerMySQLModifyTools(server, cfg)
+	registerMySQLBackupTools(server, cfg)
+	registerMySQLExportTools(server, cfg)
+	registerMySQLJobTools(server, cfg)
+	registerMySQLWaitTools(server, cfg)
+	registerMySQLParameterTools(server, cfg)
+	registerMySQLSecurityGroupTools(server, cfg)
+	registerMySQLUserTools(server, cfg)
+	registerMySQLReplicaTools(server, cfg)
+	registerMySQLHATools(server, cfg)
+	registerMySQLMetricTools(server, cfg)
+	registerMySQLEventTools(server, cfg)
+	registerMySQLLogTools(server, cfg)
+	registerMySQLSlowLogTools(server, cfg)
+	registerMySQLNotificationTools(server, cfg)
+	registerMySQLCatalogTools(server, cfg)
+	registerMySQLBackupSettingsTools(server, cfg)
 }
 
 // Tool implementations
 
 func listMySQLInstances(ctx context.Context, cfg *config.Config) (ListMySQLInstancesOutput, error) {
-	client, err := cfg.NewNHNCloudClient()
+	api, err := newMySQLAPI(cfg)
 	if err != nil {
-		return ListMySQLInstancesOutput{}, fmt.Errorf("failed to create client: %w", err)
+		return ListMySQLInstancesOutput{}, err
 	}
 
-	result, err := client.MySQL().ListInstances(ctx)
+	records, err := fetchMySQLInstanceRecords(ctx, api)
 	if err != nil {
-		return ListMySQLInstancesOutput{}, fmt.Errorf("failed to list instances: %w", err)
+		return ListMySQLInstancesOutput{}, err
 	}
 
-	instances := make([]MySQLInstance, 0, len(result.DBInstances))
-	for _, inst := range result.DBInstances {
-		instances = append(instances, MySQLInstance{
-			ID:          inst.DBInstanceID,
-			Name:        inst.DBInstanceName,
-			Status:      inst.DBInstanceStatus,
-			Version:     inst.DBVersion,
-			StorageType: inst.StorageType,
-			StorageSize: inst.StorageSize,
-		})
+	instances := make([]MySQLInstance, 0, len(records))
+	for _, rec := range records {
+		instances = append(instances, newMySQLInstance(rec))
 	}
 
 	return ListMySQLInstancesOutput{
@@ -185,27 +253,36 @@ func getMySQLInstance(ctx context.Context, cfg *config.Config, instanceID string
 		return GetMySQLInstanceOutput{}, fmt.Errorf("instance_id is required")
 	}
 
-	client, err := cfg.NewNHNCloudClient()
+	api, err := newMySQLAPI(cfg)
 	if err != nil {
-		return GetMySQLInstanceOutput{}, fmt.Errorf("failed to create client: %w", err)
+		return GetMySQLInstanceOutput{}, err
 	}
 
-	result, err := client.MySQL().GetInstance(ctx, instanceID)
+	rec, err := fetchMySQLInstanceRecord(ctx, api, instanceID)
 	if err != nil {
-		return GetMySQLInstanceOutput{}, fmt.Errorf("failed to get instance: %w", err)
+		return GetMySQLInstanceOutput{}, err
 	}
+	return GetMySQLInstanceOutput{Instance: newMySQLInstance(rec)}, nil
+}
 
-	// DatabaseInstanceResponse embeds DatabaseInstance, so fields are accessed directly
-	return GetMySQLInstanceOutput{
-		Instance: MySQLInstance{
-			ID:          result.DBInstanceID,
-			Name:        result.DBInstanceName,
-			Status:      result.DBInstanceStatus,
-			Version:     result.DBVersion,
-			StorageType: result.StorageType,
-			StorageSize: result.StorageSize,
-		},
-	}, nil
+// fetchMySQLInstanceRecords lists instances through the API rather than the
+// SDK, which drops the role and group of each instance
+func fetchMySQLInstanceRecords(ctx context.Context, api *mysqlAPI) ([]mysqlInstanceRecord, error) {
+	var result struct {
+		DBInstances []mysqlInstanceRecord `json:"dbInstances"`
+	}
+	if err := api.get(ctx, "/db-instances", nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to list instances: %w", err)
+	}
+	return result.DBInstances, nil
+}
+
+func fetchMySQLInstanceRecord(ctx context.Context, api *mysqlAPI, instanceID string) (mysqlInstanceRecord, error) {
+	var rec mysqlInstanceRecord
+	if err := api.get(ctx, "/db-instances/"+url.PathEscape(instanceID), nil, &rec); err != nil {
+		return mysqlInstanceRecord{}, fmt.Errorf("failed to get instance: %w", err)
+	}
+	return rec, nil
 }
 
 func listMySQLFlavors(ctx context.Context, cfg *config.Config) (ListMySQLFlavorsOutput, error) {
@@ -235,30 +312,123 @@ func listMySQLFlavors(ctx context.Context, cfg *config.Config) (ListMySQLFlavors
 	}, nil
 }
 
-func listMySQLBackups(ctx context.Context, cfg *config.Config, instanceID string) (ListMySQLBackupsOutput, error) {
-	client, err := cfg.NewNHNCloudClient()
-	if err != nil {
-		return ListMySQLBackupsOutput{}, fmt.Errorf("failed to create client: %w", err)
+func listMySQLBackups(ctx context.Context, cfg *config.Config, in ListMySQLBackupsInput) (ListMySQLBackupsOutput, error) {
+	var problems validationErrors
+	filter := url.Values{}
+	if in.InstanceID != "" {
+		filter.Set("dbInstanceId", in.InstanceID)
+	}
+	switch strings.ToLower(in.BackupType) {
+	case "":
+	case "auto", "manual":
+		filter.Set("backupType", strings.ToUpper(in.BackupType))
+	default:
+		problems.addf("backup_type must be auto or manual, got %q", in.BackupType)
+	}
+	var from, to time.Time
+	if in.From != "" {
+		t, err := time.Parse(time.RFC3339, in.From)
+		if err != nil {
+			problems.addf("from %q is not an RFC 3339 time", in.From)
+		}
+		from = t
+	}
+	if in.To != "" {
+		t, err := time.Parse(time.RFC3339, in.To)
+		if err != nil {
+			problems.addf("to %q is not an RFC 3339 time", in.To)
+		}
+		to = t
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		problems.addf("from must be before to")
+	}
+	page := in.Page
+	if page == 0 {
+		page = 1
+	} else if page < 0 {
+		problems.addf("page must be 1 or more")
+	}
+	size := in.Size
+	if size == 0 {
+		size = mysqlBackupPageSize
+	} else if size < 0 || size > mysqlBackupPageSize {
+		problems.addf("size must be between 1 and %d", mysqlBackupPageSize)
+	}
+	maxPages := 1
+	if in.AllPages {
+		maxPages = in.MaxPages
+		if maxPages == 0 {
+			maxPages = mysqlBackupMaxPages
+		} else if maxPages < 0 || maxPages > mysqlBackupMaxPages {
+			problems.addf("max_pages must be between 1 and %d", mysqlBackupMaxPages)
+		}
+	}
+	if err := problems.err(); err != nil {
+		return ListMySQLBackupsOutput{}, err
 	}
 
-	result, err := client.MySQL().ListBackups(ctx, instanceID, "", 0, 100)
+	api, err := newMySQLAPI(cfg)
 	if err != nil {
-		return ListMySQLBackupsOutput{}, fmt.Errorf("failed to list backups: %w", err)
+		return ListMySQLBackupsOutput{}, err
 	}
 
-	backups := make([]MySQLBackup, 0, len(result.Backups))
-	for _, b := range result.Backups {
-		backups = append(backups, MySQLBackup{
-			ID:         b.BackupID,
-			InstanceID: b.DBInstanceID,
-			Status:     b.BackupStatus,
-			Size:       b.BackupSize,
-			CreatedAt:  b.CreatedYmdt,
-		})
+	out := ListMySQLBackupsOutput{Backups: []MySQLBackup{}, Page: page, Size: size}
+	for p := page; out.PagesRead < maxPages; p++ {
+		backups, total, err := fetchMySQLBackupPage(ctx, api, filter, p, size)
+		if err != nil {
+			return ListMySQLBackupsOutput{}, err
+		}
+		out.PagesRead++
+		out.Total = total
+		out.NextPage = 0
+		if len(backups) == size && p*size < total {
+			out.NextPage = p + 1
+		}
+
+		out.Scanned += len(backups)
+		for _, b := range backups {
+			// The API has no date filter, so the range is applied here. Backups
+			// whose time cannot be read are kept and flagged.
+			if !from.IsZero() || !to.IsZero() {
+				created, err := time.Parse(time.RFC3339, b.CreatedYmdt)
+				if err != nil {
+					out.Undated = append(out.Undated, b.BackupID)
+				} else if (!from.IsZero() && created.Before(from)) || (!to.IsZero() && !created.Before(to)) {
+					continue
+				}
+			}
+			out.Backups = append(out.Backups, MySQLBackup{
+				ID:         b.BackupID,
+				Name:       b.BackupName,
+				InstanceID: b.DBInstanceID,
+				Type:       b.BackupType,
+				Status:     b.BackupStatus,
+				Version:    b.DBVersion,
+				Size:       b.BackupSize,
+				CreatedAt:  b.CreatedYmdt,
+			})
+		}
+		if out.NextPage == 0 {
+			break
+		}
 	}
+	out.Count = len(out.Backups)
+	out.Truncated = out.NextPage != 0
+	return out, nil
+}
 
-	return ListMySQLBackupsOutput{
-		Backups: backups,
-		Count:   len(backups),
-	}, nil
+func formatMySQLBackupList(out ListMySQLBackupsOutput) string {
+	text := fmt.Sprintf("Found %d MySQL backups", out.Count)
+	if out.Count != out.Scanned {
+		text += fmt.Sprintf(" in the date range, out of %d read", out.Scanned)
+	}
+	text += fmt.Sprintf(" (pages %d-%d of size %d; %d backups in total before any date filter)", out.Page, out.Page+out.PagesRead-1, out.Size, out.Total)
+	if len(out.Undated) > 0 {
+		text += fmt.Sprintf("\n%d backups have an unreadable creation time and were kept: %s", len(out.Undated), strings.Join(out.Undated, ", "))
+	}
+	if out.Truncated {
+		text += fmt.Sprintf("\nMore pages remain, continue with page %d", out.NextPage)
+	}
+	return text
 }
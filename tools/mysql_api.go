@@ -0,0 +1,167 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+)
+
+// mysqlAPIURLFormat is the RDS for MySQL v3.0 endpoint for a region
+const mysqlAPIURLFormat = "https://%s-rds-mysql.api.nhncloudservice.com/v3.0"
+
+// mysqlAPI calls RDS for MySQL endpoints that the SDK is missing or builds
+// incorrectly (the SDK escapes the "?" of query strings into the path)
+type mysqlAPI struct {
+	baseURL         string
+	appKey          string
+	accessKeyID     string
+	secretAccessKey string
+	http            *http.Client
+}
+
+func newMySQLAPI(cfg *config.Config) (*mysqlAPI, error) {
+	region, appKey, accessKeyID, secretAccessKey := cfg.MySQLAPICredentials()
+	if appKey == "" || accessKeyID == "" || secretAccessKey == "" {
+		return nil, fmt.Errorf("RDS MySQL credentials are not configured (need MySQL app key, access key ID and secret access key)")
+	}
+	return &mysqlAPI{
+		baseURL:         fmt.Sprintf(mysqlAPIURLFormat, strings.ToLower(region)),
+		appKey:          appKey,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		http:            &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+// mysqlAPIHeader is the result header of every RDS API response
+type mysqlAPIHeader struct {
+	ResultCode    int    `json:"resultCode"`
+	ResultMessage string `json:"resultMessage"`
+	IsSuccessful  bool   `json:"isSuccessful"`
+}
+
+// mysqlAPIError is a failed RDS API call. StatusCode is the HTTP status and
+// ResultCode the code from the result header, when the response had one.
+type mysqlAPIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	ResultCode int
+	Message    string
+}
+
+func (e *mysqlAPIError) Error() string {
+	if e.ResultCode != 0 {
+		return fmt.Sprintf("%s %s: API error %d: %s", e.Method, e.Path, e.ResultCode, e.Message)
+	}
+	return fmt.Sprintf("%s %s: HTTP %d: %s", e.Method, e.Path, e.StatusCode, e.Message)
+}
+
+// notFound reports whether the resource does not exist. The API answers
+// some lookups of missing resources with HTTP 200 and a failed result
+// header, so the message is checked as well.
+func (e *mysqlAPIError) notFound() bool {
+	if e.StatusCode == http.StatusNotFound {
+		return true
+	}
+	msg := strings.ToLower(e.Message)
+	return strings.Contains(msg, "not found") || strings.Contains(msg, "not exist")
+}
+
+// isMySQLNotFound reports whether err is an RDS API error for a missing resource
+func isMySQLNotFound(err error) bool {
+	var apiErr *mysqlAPIError
+	return errors.As(err, &apiErr) && apiErr.notFound()
+}
+
+// mysqlAPIJob is the response of asynchronous operations
+type mysqlAPIJob struct {
+	JobID string `json:"jobId"`
+}
+
+func (a *mysqlAPI) get(ctx context.Context, path string, query url.Values, out any) error {
+	return a.do(ctx, http.MethodGet, path, query, nil, out)
+}
+
+func (a *mysqlAPI) post(ctx context.Context, path string, body, out any) error {
+	return a.do(ctx, http.MethodPost, path, nil, body, out)
+}
+
+func (a *mysqlAPI) put(ctx context.Context, path string, body, out any) error {
+	return a.do(ctx, http.MethodPut, path, nil, body, out)
+}
+
+func (a *mysqlAPI) delete(ctx context.Context, path string, out any) error {
+	return a.do(ctx, http.MethodDelete, path, nil, nil, out)
+}
+
+func (a *mysqlAPI) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	u := a.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-TC-APP-KEY", a.appKey)
+	req.Header.Set("X-TC-AUTHENTICATION-ID", a.accessKeyID)
+	req.Header.Set("X-TC-AUTHENTICATION-SECRET", a.secretAccessKey)
+
+	resp, err := a.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s %s: failed to read response: %w", method, path, err)
+	}
+
+	var envelope struct {
+		Header *mysqlAPIHeader `json:"header"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Header == nil {
+		if resp.StatusCode >= 400 {
+			return &mysqlAPIError{Method: method, Path: path, StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+		}
+		return fmt.Errorf("%s %s: unexpected response: %s", method, path, strings.TrimSpace(string(data)))
+	}
+	if !envelope.Header.IsSuccessful {
+		return &mysqlAPIError{
+			Method:     method,
+			Path:       path,
+			StatusCode: resp.StatusCode,
+			ResultCode: envelope.Header.ResultCode,
+			Message:    envelope.Header.ResultMessage,
+		}
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("%s %s: failed to decode response: %w", method, path, err)
+		}
+	}
+	return nil
+}
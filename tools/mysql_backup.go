@@ -0,0 +1,648 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/rds/mysql"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// mysqlBackupCompleted is the status of a usable backup
+const mysqlBackupCompleted = "COMPLETED"
+
+const (
+	// mysqlBackupPageSize is the largest page the backup list API returns
+	mysqlBackupPageSize = 100
+	// mysqlBackupMaxPages bounds how far backup lookups walk the list
+	mysqlBackupMaxPages = 20
+)
+
+var mysqlBackupNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9._-]{0,99}$`)
+
+// mysqlBackupInfo is the automatic backup configuration of an instance
+type mysqlBackupInfo struct {
+	BackupPeriod      int                    `json:"backupPeriod"`
+	BackupRetryCount  int                    `json:"backupRetryCount"`
+	ReplicationRegion string                 `json:"replicationRegion"`
+	UseBackupLock     bool                   `json:"useBackupLock"`
+	BackupSchedules   []mysql.BackupSchedule `json:"backupSchedules"`
+}
+
+// mysqlRestoreRequest is the body of both restore APIs; Restore is only
+// set for point-in-time restores
+type mysqlRestoreRequest struct {
+	Restore               *mysqlRestoreSpec `json:"restore,omitempty"`
+	DBInstanceName        string            `json:"dbInstanceName"`
+	DBFlavorID            string            `json:"dbFlavorId"`
+	DBPort                int               `json:"dbPort"`
+	ParameterGroupID      string            `json:"parameterGroupId"`
+	DBSecurityGroupIDs    []string          `json:"dbSecurityGroupIds,omitempty"`
+	Network               mysql.Network     `json:"network"`
+	Storage               mysql.Storage     `json:"storage"`
+	UseDeletionProtection bool              `json:"useDeletionProtection"`
+}
+
+type mysqlRestoreSpec struct {
+	RestoreType string `json:"restoreType"`
+	RestoreYmdt string `json:"restoreYmdt"`
+}
+
+type CreateMySQLBackupInput struct {
+	InstanceID string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance to back up"`
+	BackupName string `json:"backup_name" jsonschema_description:"Backup name: 1-100 characters, letters, digits, '.', '_' and '-', starting with a letter"`
+}
+
+type DeleteMySQLBackupInput struct {
+	BackupID    string `json:"backup_id" jsonschema_description:"The ID of the backup to delete"`
+	ConfirmName string `json:"confirm_name" jsonschema_description:"The backup name, repeated to confirm deletion. Deletion is irreversible."`
+}
+
+// MySQLBackupJobOutput - output for backup creation and deletion
+type MySQLBackupJobOutput struct {
+	BackupID   string `json:"backup_id,omitempty"`
+	InstanceID string `json:"instance_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	JobID      string `json:"job_id"`
+}
+
+type RestoreMySQLBackupInput struct {
+	BackupID           string   `json:"backup_id" jsonschema_description:"The ID of the backup to restore"`
+	Name               string   `json:"name" jsonschema_description:"Name of the new instance"`
+	Flavor             string   `json:"flavor,omitempty" jsonschema_description:"Flavor ID or name (default: the source instance's)"`
+	SubnetID           string   `json:"subnet_id,omitempty" jsonschema_description:"Subnet ID (default: the source instance's)"`
+	AvailabilityZone   string   `json:"availability_zone,omitempty" jsonschema_description:"Availability zone (default: the source instance's)"`
+	StorageType        string   `json:"storage_type,omitempty" jsonschema_description:"Storage type (default: the source instance's)"`
+	StorageSizeGB      int      `json:"storage_size_gb,omitempty" jsonschema_description:"Storage size in GB, at least the source instance's (default: the source instance's)"`
+	Port               int      `json:"port,omitempty" jsonschema_description:"DB port (default: the source instance's)"`
+	ParameterGroupID   string   `json:"parameter_group_id,omitempty" jsonschema_description:"Parameter group ID for the backup's DB version (default: the source instance's)"`
+	SecurityGroupIDs   []string `json:"security_group_ids,omitempty" jsonschema_description:"DB security group IDs (default: the source instance's)"`
+	UsePublicAccess    bool     `json:"use_public_access,omitempty" jsonschema_description:"Assign a public endpoint"`
+	DeletionProtection bool     `json:"deletion_protection,omitempty" jsonschema_description:"Enable deletion protection on the new instance"`
+}
+
+type RestoreMySQLToTimeInput struct {
+	InstanceID         string   `json:"instance_id" jsonschema_description:"The ID of the source MySQL instance"`
+	RestoreTime        string   `json:"restore_time" jsonschema_description:"Point in time to restore to, RFC 3339 (e.g. 2024-05-01T13:45:00+09:00); must fall within the restore window"`
+	Name               string   `json:"name" jsonschema_description:"Name of the new instance"`
+	Flavor             string   `json:"flavor,omitempty" jsonschema_description:"Flavor ID or name (default: the source instance's)"`
+	SubnetID           string   `json:"subnet_id,omitempty" jsonschema_description:"Subnet ID (default: the source instance's)"`
+	AvailabilityZone   string   `json:"availability_zone,omitempty" jsonschema_description:"Availability zone (default: the source instance's)"`
+	StorageType        string   `json:"storage_type,omitempty" jsonschema_description:"Storage type (default: the source instance's)"`
+	StorageSizeGB      int      `json:"storage_size_gb,omitempty" jsonschema_description:"Storage size in GB, at least the source instance's (default: the source instance's)"`
+	Port               int      `json:"port,omitempty" jsonschema_description:"DB port (default: the source instance's)"`
+	ParameterGroupID   string   `json:"parameter_group_id,omitempty" jsonschema_description:"Parameter group ID (default: the source instance's)"`
+	SecurityGroupIDs   []string `json:"security_group_ids,omitempty" jsonschema_description:"DB security group IDs (default: the source instance's)"`
+	UsePublicAccess    bool     `json:"use_public_access,omitempty" jsonschema_description:"Assign a public endpoint"`
+	DeletionProtection bool     `json:"deletion_protection,omitempty" jsonschema_description:"Enable deletion protection on the new instance"`
+}
+
+// RestoreMySQLOutput - output for restoring into a new instance
+type RestoreMySQLOutput struct {
+	JobID            string `json:"job_id"`
+	Name             string `json:"name"`
+	SourceInstanceID string `json:"source_instance_id,omitempty"`
+	BackupID         string `json:"backup_id,omitempty"`
+	RestoreTime      string `json:"restore_time,omitempty"`
+	FlavorName       string `json:"flavor_name"`
+	StorageSizeGB    int    `json:"storage_size_gb"`
+}
+
+type GetMySQLRestoreWindowInput struct {
+	InstanceID string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+}
+
+// MySQLRestoreWindow is the range of times an instance can be restored to
+type MySQLRestoreWindow struct {
+	InstanceID     string `json:"instance_id"`
+	RetentionDays  int    `json:"retention_days"`
+	Earliest       string `json:"earliest"`
+	Latest         string `json:"latest"`
+	OldestBackupID string `json:"oldest_backup_id"`
+}
+
+func registerMySQLBackupTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_create_backup",
+		Description: "Take a manual backup of an AVAILABLE NHN Cloud RDS MySQL instance. Manual backups are kept until deleted.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[CreateMySQLBackupInput]) (*mcp.CallToolResultFor[MySQLBackupJobOutput], error) {
+		out, err := createMySQLBackup(ctx, cfg, params.Arguments.InstanceID, params.Arguments.BackupName)
+		if err != nil {
+			return &mcp.CallToolResultFor[MySQLBackupJobOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[MySQLBackupJobOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Creating backup %s of instance %s (job %s)", out.Name, out.InstanceID, out.JobID)}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_delete_backup",
+		Description: "Permanently delete an NHN Cloud RDS MySQL backup. confirm_name must repeat the backup name.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[DeleteMySQLBackupInput]) (*mcp.CallToolResultFor[MySQLBackupJobOutput], error) {
+		out, err := deleteMySQLBackup(ctx, cfg, params.Arguments.BackupID, params.Arguments.ConfirmName)
+		if err != nil {
+			return &mcp.CallToolResultFor[MySQLBackupJobOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[MySQLBackupJobOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Deleting backup %s (job %s)", out.BackupID, out.JobID)}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_restore_backup",
+		Description: "Restore an NHN Cloud RDS MySQL backup into a new instance. Settings not given are copied from the backup's source instance; if that instance no longer exists, flavor, subnet, availability zone, storage and parameter group are required.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[RestoreMySQLBackupInput]) (*mcp.CallToolResultFor[RestoreMySQLOutput], error) {
+		out, err := restoreMySQLBackup(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[RestoreMySQLOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[RestoreMySQLOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Restoring backup %s into new instance %s (job %s)", out.BackupID, out.Name, out.JobID)}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_get_restore_window",
+		Description: "Show the time range an NHN Cloud RDS MySQL instance can be restored to with nhn_mysql_restore_to_time, based on its backup retention and oldest completed backup.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[GetMySQLRestoreWindowInput]) (*mcp.CallToolResultFor[MySQLRestoreWindow], error) {
+		out, err := getMySQLRestoreWindow(ctx, cfg, params.Arguments.InstanceID)
+		if err != nil {
+			return &mcp.CallToolResultFor[MySQLRestoreWindow]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[MySQLRestoreWindow]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Instance %s can be restored to any time from %s to %s", out.InstanceID, out.Earliest, out.Latest)}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_restore_to_time",
+		Description: "Point-in-time restore of an NHN Cloud RDS MySQL instance into a new instance. The restore time must fall within the instance's restore window (see nhn_mysql_get_restore_window). Settings not given are copied from the source instance.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[RestoreMySQLToTimeInput]) (*mcp.CallToolResultFor[RestoreMySQLOutput], error) {
+		out, err := restoreMySQLToTime(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[RestoreMySQLOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[RestoreMySQLOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Restoring instance %s as of %s into new instance %s (job %s)", out.SourceInstanceID, out.RestoreTime, out.Name, out.JobID)}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func createMySQLBackup(ctx context.Context, cfg *config.Config, instanceID, backupName string) (MySQLBackupJobOutput, error) {
+	if !mysqlBackupNamePattern.MatchString(backupName) {
+		return MySQLBackupJobOutput{}, fmt.Errorf("backup_name %q must be 1-100 characters of letters, digits, '.', '_' or '-', starting with a letter", backupName)
+	}
+
+	inst, err := getMySQLInstance(ctx, cfg, instanceID)
+	if err != nil {
+		return MySQLBackupJobOutput{}, err
+	}
+	if inst.Instance.Status != mysqlStatusAvailable {
+		return MySQLBackupJobOutput{}, fmt.Errorf("cannot back up instance %s (%s): status is %s, expected %s",
+			inst.Instance.Name, instanceID, inst.Instance.Status, mysqlStatusAvailable)
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLBackupJobOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	result, err := client.MySQL().CreateBackup(ctx, instanceID, &mysql.CreateBackupInput{BackupName: backupName})
+	if err != nil {
+		return MySQLBackupJobOutput{}, fmt.Errorf("failed to create backup: %w", err)
+	}
+	return MySQLBackupJobOutput{InstanceID: instanceID, Name: backupName, JobID: result.JobID}, nil
+}
+
+func deleteMySQLBackup(ctx context.Context, cfg *config.Config, backupID, confirmName string) (MySQLBackupJobOutput, error) {
+	if backupID == "" {
+		return MySQLBackupJobOutput{}, fmt.Errorf("backup_id is required")
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return MySQLBackupJobOutput{}, err
+	}
+	backup, err := findMySQLBackup(ctx, api, backupID)
+	if err != nil {
+		return MySQLBackupJobOutput{}, err
+	}
+	if err := checkMySQLConfirmName("deletion", "backup "+backupID, confirmName, backup.BackupName); err != nil {
+		return MySQLBackupJobOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLBackupJobOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	result, err := client.MySQL().DeleteBackup(ctx, backupID)
+	if err != nil {
+		return MySQLBackupJobOutput{}, fmt.Errorf("failed to delete backup: %w", err)
+	}
+	return MySQLBackupJobOutput{BackupID: backupID, Name: backup.BackupName, JobID: result.JobID}, nil
+}
+
+// mysqlRestoreTarget holds the settings of the instance a restore creates
+type mysqlRestoreTarget struct {
+	Name               string
+	Flavor             string
+	SubnetID           string
+	AvailabilityZone   string
+	StorageType        string
+	StorageSizeGB      int
+	Port               int
+	ParameterGroupID   string
+	SecurityGroupIDs   []string
+	UsePublicAccess    bool
+	DeletionProtection bool
+}
+
+func restoreMySQLBackup(ctx context.Context, cfg *config.Config, in RestoreMySQLBackupInput) (RestoreMySQLOutput, error) {
+	if in.BackupID == "" {
+		return RestoreMySQLOutput{}, fmt.Errorf("backup_id is required")
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return RestoreMySQLOutput{}, err
+	}
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return RestoreMySQLOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	rds := client.MySQL()
+
+	backup, err := findMySQLBackup(ctx, api, in.BackupID)
+	if err != nil {
+		return RestoreMySQLOutput{}, err
+	}
+	if backup.BackupStatus != mysqlBackupCompleted {
+		return RestoreMySQLOutput{}, fmt.Errorf("backup %s is %s; only %s backups can be restored", in.BackupID, backup.BackupStatus, mysqlBackupCompleted)
+	}
+
+	req, flavor, err := buildMySQLRestoreRequest(ctx, api, rds, backup.DBInstanceID, backup.DBVersion, mysqlRestoreTarget{
+		Name:               in.Name,
+		Flavor:             in.Flavor,
+		SubnetID:           in.SubnetID,
+		AvailabilityZone:   in.AvailabilityZone,
+		StorageType:        in.StorageType,
+		StorageSizeGB:      in.StorageSizeGB,
+		Port:               in.Port,
+		ParameterGroupID:   in.ParameterGroupID,
+		SecurityGroupIDs:   in.SecurityGroupIDs,
+		UsePublicAccess:    in.UsePublicAccess,
+		DeletionProtection: in.DeletionProtection,
+	})
+	if err != nil {
+		return RestoreMySQLOutput{}, err
+	}
+
+	var job mysqlAPIJob
+	if err := api.post(ctx, "/backups/"+url.PathEscape(in.BackupID)+"/restore", req, &job); err != nil {
+		return RestoreMySQLOutput{}, fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	return RestoreMySQLOutput{
+		JobID:            job.JobID,
+		Name:             req.DBInstanceName,
+		SourceInstanceID: backup.DBInstanceID,
+		BackupID:         in.BackupID,
+		FlavorName:       flavor.FlavorName,
+		StorageSizeGB:    req.Storage.StorageSize,
+	}, nil
+}
+
+func restoreMySQLToTime(ctx context.Context, cfg *config.Config, in RestoreMySQLToTimeInput) (RestoreMySQLOutput, error) {
+	restoreTime, err := time.Parse(time.RFC3339, in.RestoreTime)
+	if err != nil {
+		return RestoreMySQLOutput{}, fmt.Errorf("restore_time %q must be RFC 3339, e.g. 2024-05-01T13:45:00+09:00", in.RestoreTime)
+	}
+
+	window, err := getMySQLRestoreWindow(ctx, cfg, in.InstanceID)
+	if err != nil {
+		return RestoreMySQLOutput{}, err
+	}
+	earliest, _ := time.Parse(time.RFC3339, window.Earliest)
+	latest, _ := time.Parse(time.RFC3339, window.Latest)
+	if restoreTime.Before(earliest) || restoreTime.After(latest) {
+		return RestoreMySQLOutput{}, fmt.Errorf("restore_time %s is outside the restore window of instance %s: %s to %s (retention %d days)",
+			in.RestoreTime, in.InstanceID, window.Earliest, window.Latest, window.RetentionDays)
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return RestoreMySQLOutput{}, err
+	}
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return RestoreMySQLOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	rds := client.MySQL()
+
+	req, flavor, err := buildMySQLRestoreRequest(ctx, api, rds, in.InstanceID, "", mysqlRestoreTarget{
+		Name:               in.Name,
+		Flavor:             in.Flavor,
+		SubnetID:           in.SubnetID,
+		AvailabilityZone:   in.AvailabilityZone,
+		StorageType:        in.StorageType,
+		StorageSizeGB:      in.StorageSizeGB,
+		Port:               in.Port,
+		ParameterGroupID:   in.ParameterGroupID,
+		SecurityGroupIDs:   in.SecurityGroupIDs,
+		UsePublicAccess:    in.UsePublicAccess,
+		DeletionProtection: in.DeletionProtection,
+	})
+	if err != nil {
+		return RestoreMySQLOutput{}, err
+	}
+	req.Restore = &mysqlRestoreSpec{
+		RestoreType: "TIMESTAMP",
+		RestoreYmdt: restoreTime.Format(time.RFC3339),
+	}
+
+	var job mysqlAPIJob
+	if err := api.post(ctx, "/db-instances/"+url.PathEscape(in.InstanceID)+"/restore", req, &job); err != nil {
+		return RestoreMySQLOutput{}, fmt.Errorf("failed to restore instance: %w", err)
+	}
+
+	return RestoreMySQLOutput{
+		JobID:            job.JobID,
+		Name:             req.DBInstanceName,
+		SourceInstanceID: in.InstanceID,
+		RestoreTime:      req.Restore.RestoreYmdt,
+		FlavorName:       flavor.FlavorName,
+		StorageSizeGB:    req.Storage.StorageSize,
+	}, nil
+}
+
+// buildMySQLRestoreRequest validates target and fills unset settings from
+// the source instance. dbVersion, when set, is the version the restored
+// instance runs; otherwise the source instance's version is used.
+func buildMySQLRestoreRequest(ctx context.Context, api *mysqlAPI, rds *mysql.Client, sourceID, dbVersion string, target mysqlRestoreTarget) (*mysqlRestoreRequest, mysql.DBFlavor, error) {
+	var problems validationErrors
+	if !mysqlInstanceNamePattern.MatchString(target.Name) {
+		problems.addf("name %q must be 1-100 characters of letters, digits, '.', '_' or '-', starting with a letter", target.Name)
+	}
+
+	// The source instance may be gone when restoring an old backup; any
+	// other failure to look it up is an error
+	var source *mysqlInstanceRecord
+	if sourceID != "" {
+		rec, err := fetchMySQLInstanceRecord(ctx, api, sourceID)
+		switch {
+		case err == nil:
+			source = &rec
+		case !isMySQLNotFound(err):
+			return nil, mysql.DBFlavor{}, fmt.Errorf("failed to look up source instance %s: %w", sourceID, err)
+		}
+	}
+	minStorage := mysqlMinStorageGB
+	if source != nil {
+		if dbVersion == "" {
+			dbVersion = source.DBVersion
+		}
+		minStorage = source.StorageSize
+		if target.Flavor == "" {
+			target.Flavor = source.DBFlavorID
+		}
+		if target.SubnetID == "" {
+			target.SubnetID = source.SubnetID
+		}
+		if target.StorageType == "" {
+			target.StorageType = source.StorageType
+		}
+		if target.StorageSizeGB == 0 {
+			target.StorageSizeGB = source.StorageSize
+		}
+		if target.Port == 0 {
+			target.Port = source.DBPort
+		}
+		if target.ParameterGroupID == "" {
+			target.ParameterGroupID = source.ParameterGroupID
+		}
+		if target.SecurityGroupIDs == nil {
+			target.SecurityGroupIDs = source.DBSecurityGroupIDs
+		}
+		if target.AvailabilityZone == "" {
+			network, err := rds.GetNetworkInfo(ctx, sourceID)
+			if err != nil {
+				return nil, mysql.DBFlavor{}, fmt.Errorf("failed to get network info of source instance: %w", err)
+			}
+			target.AvailabilityZone = network.AvailabilityZone
+		}
+	}
+	if target.Port == 0 {
+		target.Port = mysqlDefaultPort
+	}
+
+	for _, f := range []struct{ name, value string }{
+		{"flavor", target.Flavor},
+		{"subnet_id", target.SubnetID},
+		{"availability_zone", target.AvailabilityZone},
+		{"storage_type", target.StorageType},
+		{"parameter_group_id", target.ParameterGroupID},
+	} {
+		switch {
+		case f.value != "":
+		case source != nil:
+			problems.addf("%s is required because it could not be derived from source instance %s", f.name, sourceID)
+		case sourceID != "":
+			problems.addf("%s is required because source instance %s no longer exists", f.name, sourceID)
+		default:
+			problems.addf("%s is required", f.name)
+		}
+	}
+	if target.StorageSizeGB < minStorage || target.StorageSizeGB > mysqlMaxStorageGB {
+		problems.addf("storage_size_gb %d must be between %d and %d", target.StorageSizeGB, minStorage, mysqlMaxStorageGB)
+	}
+	if target.Port < mysqlMinPort || target.Port > mysqlMaxPort {
+		problems.addf("port %d must be between %d and %d", target.Port, mysqlMinPort, mysqlMaxPort)
+	}
+
+	var flavor mysql.DBFlavor
+	if target.Flavor != "" {
+		f, err := resolveMySQLFlavor(ctx, rds, target.Flavor)
+		if err != nil {
+			problems.addf("%v", err)
+		}
+		flavor = f
+	}
+
+	if target.ParameterGroupID != "" && dbVersion != "" {
+		groups, err := rds.ListParameterGroups(ctx, "")
+		if err != nil {
+			return nil, mysql.DBFlavor{}, fmt.Errorf("failed to list parameter groups: %w", err)
+		}
+		found := false
+		for _, g := range groups.ParameterGroups {
+			if g.ParameterGroupID != target.ParameterGroupID {
+				continue
+			}
+			found = true
+			if g.DBVersion != dbVersion {
+				problems.addf("parameter group %s is for %s, but the restored instance runs %s", g.ParameterGroupName, g.DBVersion, dbVersion)
+			}
+		}
+		if !found {
+			problems.addf("parameter_group_id %q not found", target.ParameterGroupID)
+		}
+	}
+
+	if target.Name != "" {
+		if id, err := findMySQLInstanceIDByName(ctx, rds, target.Name); err == nil && id != "" {
+			problems.addf("an instance named %q already exists (%s)", target.Name, id)
+		}
+	}
+
+	if err := problems.err(); err != nil {
+		return nil, mysql.DBFlavor{}, err
+	}
+
+	return &mysqlRestoreRequest{
+		DBInstanceName:     target.Name,
+		DBFlavorID:         flavor.FlavorID,
+		DBPort:             target.Port,
+		ParameterGroupID:   target.ParameterGroupID,
+		DBSecurityGroupIDs: target.SecurityGroupIDs,
+		Network: mysql.Network{
+			SubnetID:         target.SubnetID,
+			AvailabilityZone: target.AvailabilityZone,
+			UsePublicAccess:  target.UsePublicAccess,
+		},
+		Storage: mysql.Storage{
+			StorageType: target.StorageType,
+			StorageSize: target.StorageSizeGB,
+		},
+		UseDeletionProtection: target.DeletionProtection,
+	}, flavor, nil
+}
+
+// getMySQLRestoreWindow derives the point-in-time restore window of an
+// instance: binary logs are kept for the backup retention period, and a
+// restore starts from the oldest completed backup inside it.
+func getMySQLRestoreWindow(ctx context.Context, cfg *config.Config, instanceID string) (MySQLRestoreWindow, error) {
+	if instanceID == "" {
+		return MySQLRestoreWindow{}, fmt.Errorf("instance_id is required")
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return MySQLRestoreWindow{}, err
+	}
+
+	info, err := fetchMySQLBackupInfo(ctx, api, instanceID)
+	if err != nil {
+		return MySQLRestoreWindow{}, err
+	}
+	if info.BackupPeriod == 0 {
+		return MySQLRestoreWindow{}, fmt.Errorf("instance %s keeps no automatic backups (retention 0 days), so point-in-time restore is unavailable", instanceID)
+	}
+
+	backups, _, err := listMySQLBackupPages(ctx, api, url.Values{"dbInstanceId": {instanceID}})
+	if err != nil {
+		return MySQLRestoreWindow{}, err
+	}
+
+	now := time.Now()
+	retentionStart := now.AddDate(0, 0, -info.BackupPeriod)
+	var oldest time.Time
+	var oldestID string
+	for _, b := range backups {
+		if b.BackupStatus != mysqlBackupCompleted {
+			continue
+		}
+		created, err := time.Parse(time.RFC3339, b.CreatedYmdt)
+		if err != nil || created.Before(retentionStart) {
+			continue
+		}
+		if oldestID == "" || created.Before(oldest) {
+			oldest, oldestID = created, b.BackupID
+		}
+	}
+	if oldestID == "" {
+		return MySQLRestoreWindow{}, fmt.Errorf("instance %s has no completed backup within its %d-day retention, so point-in-time restore is unavailable", instanceID, info.BackupPeriod)
+	}
+
+	return MySQLRestoreWindow{
+		InstanceID:     instanceID,
+		RetentionDays:  info.BackupPeriod,
+		Earliest:       oldest.Format(time.RFC3339),
+		Latest:         now.Truncate(time.Second).Format(time.RFC3339),
+		OldestBackupID: oldestID,
+	}, nil
+}
+
+// findMySQLBackup looks a backup up by ID; the API has no single-backup endpoint
+func findMySQLBackup(ctx context.Context, api *mysqlAPI, backupID string) (mysql.Backup, error) {
+	backups, _, err := listMySQLBackupPages(ctx, api, nil)
+	if err != nil {
+		return mysql.Backup{}, err
+	}
+	for _, b := range backups {
+		if b.BackupID == backupID {
+			return b, nil
+		}
+	}
+	return mysql.Backup{}, fmt.Errorf("backup %q not found", backupID)
+}
+
+// listMySQLBackupPages walks the backup list up to mysqlBackupMaxPages pages.
+// It returns the backups and the total count reported by the API.
+func listMySQLBackupPages(ctx context.Context, api *mysqlAPI, filter url.Values) ([]mysql.Backup, int, error) {
+	var all []mysql.Backup
+	total := 0
+	for page := 1; page <= mysqlBackupMaxPages; page++ {
+		backups, pageTotal, err := fetchMySQLBackupPage(ctx, api, filter, page, mysqlBackupPageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		all = append(all, backups...)
+		total = pageTotal
+		if len(backups) < mysqlBackupPageSize || len(all) >= total {
+			break
+		}
+	}
+	return all, total, nil
+}
+
+// fetchMySQLBackupPage reads one page of the backup list; pages start at 1
+func fetchMySQLBackupPage(ctx context.Context, api *mysqlAPI, filter url.Values, page, size int) ([]mysql.Backup, int, error) {
+	query := url.Values{}
+	for k, v := range filter {
+		query[k] = v
+	}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("size", strconv.Itoa(size))
+
+	var resp mysql.BackupsResponse
+	if err := api.get(ctx, "/backups", query, &resp); err != nil {
+		return nil, 0, fmt.Errorf("failed to list backups: %w", err)
+	}
+	return resp.Backups, resp.TotalCounts, nil
+}
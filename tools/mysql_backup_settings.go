@@ -0,0 +1,320 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/rds/mysql"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// mysqlMaxBackupRetries is the most times a failed automatic backup is retried
+const mysqlMaxBackupRetries = 10
+
+// mysqlBackupInfoUpdate is the body of PUT /db-instances/{id}/backup-info
+type mysqlBackupInfoUpdate struct {
+	BackupPeriod      int                    `json:"backupPeriod"`
+	BackupRetryCount  int                    `json:"backupRetryCount"`
+	ReplicationRegion string                 `json:"replicationRegion,omitempty"`
+	UseBackupLock     bool                   `json:"useBackupLock"`
+	BackupSchedules   []mysql.BackupSchedule `json:"backupSchedules"`
+}
+
+type GetMySQLBackupSettingsInput struct {
+	InstanceID string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+}
+
+// GetMySQLBackupSettingsOutput - output for getting automatic backup settings
+type GetMySQLBackupSettingsOutput struct {
+	InstanceID string              `json:"instance_id"`
+	Backup     MySQLInstanceBackup `json:"backup"`
+}
+
+type ModifyMySQLBackupSettingsInput struct {
+	InstanceID     string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+	RetentionDays  *int   `json:"retention_days,omitempty" jsonschema_description:"Days to keep automatic backups (0-730, 0 turns automatic backups off)"`
+	RetryCount     *int   `json:"retry_count,omitempty" jsonschema_description:"Times a failed automatic backup is retried (0-10)"`
+	UseTableLock   *bool  `json:"use_table_lock,omitempty" jsonschema_description:"Lock tables while backing up for a consistent copy of non-InnoDB tables"`
+	WindowStart    string `json:"window_start,omitempty" jsonschema_description:"Daily backup window start time HH:MM:SS; replaces every existing window"`
+	WindowDuration string `json:"window_duration,omitempty" jsonschema_description:"Backup window duration: HALF_AN_HOUR, ONE_HOUR, ONE_HOUR_AND_HALF, TWO_HOURS, TWO_HOURS_AND_HALF, THREE_HOURS"`
+}
+
+// ModifyMySQLBackupSettingsOutput - output for modifying automatic backup settings
+type ModifyMySQLBackupSettingsOutput struct {
+	InstanceID string              `json:"instance_id"`
+	Before     MySQLInstanceBackup `json:"before"`
+	After      MySQLInstanceBackup `json:"after"`
+	Changed    []string            `json:"changed"`
+	JobID      string              `json:"job_id,omitempty"`
+}
+
+type ReportMySQLBackupRetentionInput struct {
+	MinRetentionDays int `json:"min_retention_days,omitempty" jsonschema_description:"Flag instances keeping backups fewer days than this (default: NHN_CLOUD_MIN_BACKUP_RETENTION_DAYS, or 7)"`
+}
+
+// MySQLBackupRetention is the retention of one instance in the report
+type MySQLBackupRetention struct {
+	Instance      MySQLInstanceRef `json:"instance"`
+	Role          string           `json:"role"`
+	RetentionDays int              `json:"retention_days"`
+}
+
+// ReportMySQLBackupRetentionOutput - output for the backup retention report
+type ReportMySQLBackupRetentionOutput struct {
+	MinRetentionDays int                    `json:"min_retention_days"`
+	Checked          int                    `json:"checked"`
+	BelowMinimum     []MySQLBackupRetention `json:"below_minimum"`
+	Notes            []string               `json:"notes,omitempty"`
+}
+
+func registerMySQLBackupSettingsTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_get_backup_settings",
+		Description: "Get the automatic backup settings of an NHN Cloud RDS MySQL instance: retention days, retry count, table lock and backup windows.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[GetMySQLBackupSettingsInput]) (*mcp.CallToolResultFor[GetMySQLBackupSettingsOutput], error) {
+		out, err := getMySQLBackupSettings(ctx, cfg, params.Arguments.InstanceID)
+		if err != nil {
+			return &mcp.CallToolResultFor[GetMySQLBackupSettingsOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[GetMySQLBackupSettingsOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Instance %s: %s", out.InstanceID, formatMySQLBackupSettings(out.Backup))}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_modify_backup_settings",
+		Description: "Change the automatic backup settings of an NHN Cloud RDS MySQL instance. Only the settings given are changed; a new window replaces all existing windows.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ModifyMySQLBackupSettingsInput]) (*mcp.CallToolResultFor[ModifyMySQLBackupSettingsOutput], error) {
+		out, err := modifyMySQLBackupSettings(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[ModifyMySQLBackupSettingsOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[ModifyMySQLBackupSettingsOutput]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Changed %s on instance %s\nBefore: %s\nAfter: %s",
+				strings.Join(out.Changed, ", "), out.InstanceID, formatMySQLBackupSettings(out.Before), formatMySQLBackupSettings(out.After))}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_report_backup_retention",
+		Description: "Report NHN Cloud RDS MySQL instances whose automatic backup retention is below a minimum number of days.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ReportMySQLBackupRetentionInput]) (*mcp.CallToolResultFor[ReportMySQLBackupRetentionOutput], error) {
+		out, err := reportMySQLBackupRetention(ctx, cfg, params.Arguments.MinRetentionDays)
+		if err != nil {
+			return &mcp.CallToolResultFor[ReportMySQLBackupRetentionOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		text := fmt.Sprintf("%d of %d instances keep backups fewer than %d days", len(out.BelowMinimum), out.Checked, out.MinRetentionDays)
+		for _, r := range out.BelowMinimum {
+			text += fmt.Sprintf("\n%s (%s): %d days", r.Instance.Name, r.Instance.ID, r.RetentionDays)
+		}
+		for _, n := range out.Notes {
+			text += "\nNote: " + n
+		}
+		return &mcp.CallToolResultFor[ReportMySQLBackupRetentionOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func formatMySQLBackupSettings(b MySQLInstanceBackup) string {
+	text := fmt.Sprintf("retention %d days, %d retries, table lock %s", b.RetentionDays, b.RetryCount, onOff(b.UseTableLock))
+	for _, w := range b.Windows {
+		text += fmt.Sprintf(", window %s for %s", w.Start, w.Duration)
+	}
+	return text
+}
+
+func newMySQLInstanceBackup(info mysqlBackupInfo) MySQLInstanceBackup {
+	b := MySQLInstanceBackup{
+		RetentionDays:     info.BackupPeriod,
+		RetryCount:        info.BackupRetryCount,
+		UseTableLock:      info.UseBackupLock,
+		ReplicationRegion: info.ReplicationRegion,
+		Windows:           []MySQLBackupWindow{},
+	}
+	for _, s := range info.BackupSchedules {
+		b.Windows = append(b.Windows, MySQLBackupWindow{Start: s.BackupWndBgnTime, Duration: s.BackupWndDuration})
+	}
+	return b
+}
+
+func fetchMySQLBackupInfo(ctx context.Context, api *mysqlAPI, instanceID string) (mysqlBackupInfo, error) {
+	var info mysqlBackupInfo
+	if err := api.get(ctx, "/db-instances/"+url.PathEscape(instanceID)+"/backup-info", nil, &info); err != nil {
+		return mysqlBackupInfo{}, fmt.Errorf("failed to get backup settings: %w", err)
+	}
+	return info, nil
+}
+
+func getMySQLBackupSettings(ctx context.Context, cfg *config.Config, instanceID string) (GetMySQLBackupSettingsOutput, error) {
+	if instanceID == "" {
+		return GetMySQLBackupSettingsOutput{}, fmt.Errorf("instance_id is required")
+	}
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return GetMySQLBackupSettingsOutput{}, err
+	}
+	info, err := fetchMySQLBackupInfo(ctx, api, instanceID)
+	if err != nil {
+		return GetMySQLBackupSettingsOutput{}, err
+	}
+	return GetMySQLBackupSettingsOutput{InstanceID: instanceID, Backup: newMySQLInstanceBackup(info)}, nil
+}
+
+func modifyMySQLBackupSettings(ctx context.Context, cfg *config.Config, in ModifyMySQLBackupSettingsInput) (ModifyMySQLBackupSettingsOutput, error) {
+	var problems validationErrors
+	if in.InstanceID == "" {
+		problems.addf("instance_id is required")
+	}
+	if in.RetentionDays != nil && (*in.RetentionDays < 0 || *in.RetentionDays > mysqlMaxBackupDays) {
+		problems.addf("retention_days %d must be between 0 and %d", *in.RetentionDays, mysqlMaxBackupDays)
+	}
+	if in.RetryCount != nil && (*in.RetryCount < 0 || *in.RetryCount > mysqlMaxBackupRetries) {
+		problems.addf("retry_count %d must be between 0 and %d", *in.RetryCount, mysqlMaxBackupRetries)
+	}
+	if in.WindowStart != "" && !mysqlBackupTimePattern.MatchString(in.WindowStart) {
+		problems.addf("window_start %q must be HH:MM:SS", in.WindowStart)
+	}
+	if in.WindowDuration != "" && !slices.Contains(mysqlBackupWindowDurations, in.WindowDuration) {
+		problems.addf("window_duration %q must be one of %s", in.WindowDuration, strings.Join(mysqlBackupWindowDurations, ", "))
+	}
+	if in.RetentionDays == nil && in.RetryCount == nil && in.UseTableLock == nil && in.WindowStart == "" && in.WindowDuration == "" {
+		problems.addf("nothing to change: set retention_days, retry_count, use_table_lock, window_start or window_duration")
+	}
+	if err := problems.err(); err != nil {
+		return ModifyMySQLBackupSettingsOutput{}, err
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return ModifyMySQLBackupSettingsOutput{}, err
+	}
+	info, err := fetchMySQLBackupInfo(ctx, api, in.InstanceID)
+	if err != nil {
+		return ModifyMySQLBackupSettingsOutput{}, err
+	}
+
+	// The API replaces the whole configuration, so start from the current one
+	update := mysqlBackupInfoUpdate{
+		BackupPeriod:      info.BackupPeriod,
+		BackupRetryCount:  info.BackupRetryCount,
+		ReplicationRegion: info.ReplicationRegion,
+		UseBackupLock:     info.UseBackupLock,
+		BackupSchedules:   info.BackupSchedules,
+	}
+	var changed []string
+	if in.RetentionDays != nil && *in.RetentionDays != update.BackupPeriod {
+		update.BackupPeriod = *in.RetentionDays
+		changed = append(changed, "retention_days")
+	}
+	if in.RetryCount != nil && *in.RetryCount != update.BackupRetryCount {
+		update.BackupRetryCount = *in.RetryCount
+		changed = append(changed, "retry_count")
+	}
+	if in.UseTableLock != nil && *in.UseTableLock != update.UseBackupLock {
+		update.UseBackupLock = *in.UseTableLock
+		changed = append(changed, "use_table_lock")
+	}
+	if in.WindowStart != "" || in.WindowDuration != "" {
+		window := mysql.BackupSchedule{BackupWndBgnTime: in.WindowStart, BackupWndDuration: in.WindowDuration}
+		if len(info.BackupSchedules) > 0 {
+			if window.BackupWndBgnTime == "" {
+				window.BackupWndBgnTime = info.BackupSchedules[0].BackupWndBgnTime
+			}
+			if window.BackupWndDuration == "" {
+				window.BackupWndDuration = info.BackupSchedules[0].BackupWndDuration
+			}
+		}
+		if window.BackupWndBgnTime == "" || window.BackupWndDuration == "" {
+			return ModifyMySQLBackupSettingsOutput{}, fmt.Errorf("instance %s has no backup window yet; set both window_start and window_duration", in.InstanceID)
+		}
+		if len(info.BackupSchedules) != 1 || info.BackupSchedules[0] != window {
+			update.BackupSchedules = []mysql.BackupSchedule{window}
+			changed = append(changed, "window")
+		}
+	}
+	if len(changed) == 0 {
+		return ModifyMySQLBackupSettingsOutput{}, fmt.Errorf("instance %s already has these backup settings", in.InstanceID)
+	}
+
+	var job mysqlAPIJob
+	if err := api.put(ctx, "/db-instances/"+url.PathEscape(in.InstanceID)+"/backup-info", update, &job); err != nil {
+		return ModifyMySQLBackupSettingsOutput{}, fmt.Errorf("failed to modify backup settings: %w", err)
+	}
+
+	return ModifyMySQLBackupSettingsOutput{
+		InstanceID: in.InstanceID,
+		Before:     newMySQLInstanceBackup(info),
+		After: newMySQLInstanceBackup(mysqlBackupInfo{
+			BackupPeriod:      update.BackupPeriod,
+			BackupRetryCount:  update.BackupRetryCount,
+			ReplicationRegion: update.ReplicationRegion,
+			UseBackupLock:     update.UseBackupLock,
+			BackupSchedules:   update.BackupSchedules,
+		}),
+		Changed: changed,
+		JobID:   job.JobID,
+	}, nil
+}
+
+// reportMySQLBackupRetention checks every instance that keeps its own
+// backups. Candidate masters are skipped because they follow their master.
+func reportMySQLBackupRetention(ctx context.Context, cfg *config.Config, minDays int) (ReportMySQLBackupRetentionOutput, error) {
+	if minDays < 0 {
+		return ReportMySQLBackupRetentionOutput{}, fmt.Errorf("min_retention_days must not be negative")
+	}
+	if minDays == 0 {
+		days, err := config.MinBackupRetentionDays()
+		if err != nil {
+			return ReportMySQLBackupRetentionOutput{}, err
+		}
+		minDays = days
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return ReportMySQLBackupRetentionOutput{}, err
+	}
+	records, err := fetchMySQLInstanceRecords(ctx, api)
+	if err != nil {
+		return ReportMySQLBackupRetentionOutput{}, err
+	}
+
+	out := ReportMySQLBackupRetentionOutput{MinRetentionDays: minDays, BelowMinimum: []MySQLBackupRetention{}}
+	for _, rec := range records {
+		if rec.DBInstanceType == mysqlRoleCandidateMaster {
+			continue
+		}
+		info, err := fetchMySQLBackupInfo(ctx, api, rec.DBInstanceID)
+		if err != nil {
+			out.Notes = append(out.Notes, fmt.Sprintf("%s (%s): %v", rec.DBInstanceName, rec.DBInstanceID, err))
+			continue
+		}
+		out.Checked++
+		if info.BackupPeriod < minDays {
+			out.BelowMinimum = append(out.BelowMinimum, MySQLBackupRetention{
+				Instance:      MySQLInstanceRef{ID: rec.DBInstanceID, Name: rec.DBInstanceName},
+				Role:          rec.DBInstanceType,
+				RetentionDays: info.BackupPeriod,
+			})
+		}
+	}
+	return out, nil
+}
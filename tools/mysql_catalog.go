@@ -0,0 +1,379 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// computeURLFormat is the Compute API endpoint for a region and tenant
+const computeURLFormat = "https://%s-api-instance-infrastructure.nhncloudservice.com/v2/%s"
+
+// mysqlVersionRecord is a DB version as GET /db-versions returns it; the SDK
+// drops everything but the code and name
+type mysqlVersionRecord struct {
+	DBVersion         string `json:"dbVersion"`
+	DBVersionName     string `json:"dbVersionName"`
+	RestorableFromObs bool   `json:"restorableFromObs"`
+}
+
+// mysqlVersionNumber is a dbVersion code such as MYSQL_V8032 split into
+// 8, 0 and 32
+type mysqlVersionNumber struct {
+	major, minor, patch int
+}
+
+func parseMySQLVersion(code string) (mysqlVersionNumber, bool) {
+	digits, ok := strings.CutPrefix(strings.ToUpper(code), "MYSQL_V")
+	if !ok || len(digits) < 3 {
+		return mysqlVersionNumber{}, false
+	}
+	patch, err := strconv.Atoi(digits[2:])
+	if err != nil || digits[0] < '0' || digits[0] > '9' || digits[1] < '0' || digits[1] > '9' {
+		return mysqlVersionNumber{}, false
+	}
+	return mysqlVersionNumber{int(digits[0] - '0'), int(digits[1] - '0'), patch}, true
+}
+
+func (v mysqlVersionNumber) series() int {
+	return v.major*10 + v.minor
+}
+
+func (v mysqlVersionNumber) compare(o mysqlVersionNumber) int {
+	return cmp.Or(cmp.Compare(v.major, o.major), cmp.Compare(v.minor, o.minor), cmp.Compare(v.patch, o.patch))
+}
+
+type ListMySQLVersionsInput struct {
+	InstanceID string `json:"instance_id,omitempty" jsonschema_description:"Also report which versions this instance can be upgraded to (optional)"`
+}
+
+// MySQLVersion is a supported DB version
+type MySQLVersion struct {
+	Version            string `json:"version"`
+	Name               string `json:"name"`
+	RestorableFromFile bool   `json:"restorable_from_file"`
+	// UpgradeTargets are derived by mysqlUpgradeTargets, not read from the API
+	UpgradeTargets []string `json:"upgrade_targets"`
+}
+
+// ListMySQLVersionsOutput - output for listing DB versions
+type ListMySQLVersionsOutput struct {
+	Versions               []MySQLVersion `json:"versions"`
+	Count                  int            `json:"count"`
+	InstanceID             string         `json:"instance_id,omitempty"`
+	InstanceVersion        string         `json:"instance_version,omitempty"`
+	InstanceUpgradeTargets []string       `json:"instance_upgrade_targets,omitempty"`
+}
+
+type ListMySQLStorageTypesInput struct{}
+
+// ListMySQLStorageTypesOutput - output for listing storage types
+type ListMySQLStorageTypesOutput struct {
+	StorageTypes []string `json:"storage_types"`
+	Count        int      `json:"count"`
+}
+
+type ListMySQLSubnetsInput struct {
+	IncludeFull bool `json:"include_full,omitempty" jsonschema_description:"Also list subnets with no free IP addresses"`
+}
+
+// MySQLSubnet is a subnet instances can be placed in
+type MySQLSubnet struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	CIDR         string `json:"cidr"`
+	HasGateway   bool   `json:"has_gateway"`
+	AvailableIPs int    `json:"available_ips"`
+}
+
+// ListMySQLSubnetsOutput - output for listing subnets
+type ListMySQLSubnetsOutput struct {
+	Subnets []MySQLSubnet `json:"subnets"`
+	Count   int           `json:"count"`
+	Hidden  int           `json:"hidden,omitempty"`
+}
+
+type ListAvailabilityZonesInput struct{}
+
+// AvailabilityZone is an availability zone of the current region
+type AvailabilityZone struct {
+	Name      string `json:"name"`
+	Available bool   `json:"available"`
+}
+
+// ListAvailabilityZonesOutput - output for listing availability zones
+type ListAvailabilityZonesOutput struct {
+	Region string             `json:"region"`
+	Zones  []AvailabilityZone `json:"zones"`
+	Count  int                `json:"count"`
+}
+
+func registerMySQLCatalogTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_list_versions",
+		Description: "List the MySQL versions NHN Cloud RDS supports, whether a backup file from Object Storage can be restored into each, and which versions each can be upgraded to. Upgrade targets are not reported by the API; they are derived here from MySQL's one-series-at-a-time rule (newer versions within the same or the next release series, so 5.6 must go through 5.7 to reach 8.0), and the upgrade itself may still be refused. Pass instance_id to get the upgrade targets of a specific instance.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLVersionsInput]) (*mcp.CallToolResultFor[ListMySQLVersionsOutput], error) {
+		out, err := listMySQLVersions(ctx, cfg, params.Arguments.InstanceID)
+		if err != nil {
+			return &mcp.CallToolResultFor[ListMySQLVersionsOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		text := fmt.Sprintf("Found %d MySQL versions", out.Count)
+		if out.InstanceID != "" {
+			text += fmt.Sprintf("\nInstance %s runs %s; upgrade targets: %s", out.InstanceID, out.InstanceVersion, joinOrNone(out.InstanceUpgradeTargets))
+		}
+		return &mcp.CallToolResultFor[ListMySQLVersionsOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_list_storage_types",
+		Description: "List the storage types NHN Cloud RDS MySQL instances can use.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLStorageTypesInput]) (*mcp.CallToolResultFor[ListMySQLStorageTypesOutput], error) {
+		out, err := listMySQLStorageTypes(ctx, cfg)
+		if err != nil {
+			return &mcp.CallToolResultFor[ListMySQLStorageTypesOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[ListMySQLStorageTypesOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Storage types: %s", joinOrNone(out.StorageTypes))}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_list_subnets",
+		Description: "List the subnets NHN Cloud RDS MySQL instances can be placed in, with CIDR, free IP count and whether the subnet has an internet gateway (needed for public access). Subnets with no free IPs are hidden unless include_full is set.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLSubnetsInput]) (*mcp.CallToolResultFor[ListMySQLSubnetsOutput], error) {
+		out, err := listMySQLSubnets(ctx, cfg, params.Arguments.IncludeFull)
+		if err != nil {
+			return &mcp.CallToolResultFor[ListMySQLSubnetsOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		text := fmt.Sprintf("Found %d usable subnets", out.Count)
+		if out.Hidden > 0 {
+			text += fmt.Sprintf(" (%d full subnets hidden)", out.Hidden)
+		}
+		return &mcp.CallToolResultFor[ListMySQLSubnetsOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_list_availability_zones",
+		Description: "List the availability zones of the current NHN Cloud region that RDS MySQL instances can be placed in. Needs the API user credentials (username, API password, tenant ID).",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListAvailabilityZonesInput]) (*mcp.CallToolResultFor[ListAvailabilityZonesOutput], error) {
+		out, err := listAvailabilityZones(ctx, cfg)
+		if err != nil {
+			return &mcp.CallToolResultFor[ListAvailabilityZonesOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		names := make([]string, 0, len(out.Zones))
+		for _, z := range out.Zones {
+			if z.Available {
+				names = append(names, z.Name)
+			}
+		}
+		return &mcp.CallToolResultFor[ListAvailabilityZonesOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Available zones in %s: %s", out.Region, joinOrNone(names))}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func joinOrNone(s []string) string {
+	if len(s) == 0 {
+		return "none"
+	}
+	return strings.Join(s, ", ")
+}
+
+func listMySQLVersions(ctx context.Context, cfg *config.Config, instanceID string) (ListMySQLVersionsOutput, error) {
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return ListMySQLVersionsOutput{}, err
+	}
+
+	var result struct {
+		DBVersions []mysqlVersionRecord `json:"dbVersions"`
+	}
+	if err := api.get(ctx, "/db-versions", nil, &result); err != nil {
+		return ListMySQLVersionsOutput{}, fmt.Errorf("failed to list versions: %w", err)
+	}
+
+	out := ListMySQLVersionsOutput{Versions: make([]MySQLVersion, 0, len(result.DBVersions))}
+	for _, v := range result.DBVersions {
+		out.Versions = append(out.Versions, MySQLVersion{
+			Version:            v.DBVersion,
+			Name:               v.DBVersionName,
+			RestorableFromFile: v.RestorableFromObs,
+			UpgradeTargets:     mysqlUpgradeTargets(v.DBVersion, result.DBVersions),
+		})
+	}
+	out.Count = len(out.Versions)
+
+	if instanceID != "" {
+		rec, err := fetchMySQLInstanceRecord(ctx, api, instanceID)
+		if err != nil {
+			return ListMySQLVersionsOutput{}, err
+		}
+		out.InstanceID = instanceID
+		out.InstanceVersion = rec.DBVersion
+		out.InstanceUpgradeTargets = mysqlUpgradeTargets(rec.DBVersion, result.DBVersions)
+	}
+	return out, nil
+}
+
+// mysqlUpgradeTargets returns the versions from newer than from that are in
+// the same release series or the next one, oldest first. MySQL only supports
+// in-place upgrades one series at a time and never downgrades.
+func mysqlUpgradeTargets(from string, versions []mysqlVersionRecord) []string {
+	current, ok := parseMySQLVersion(from)
+	if !ok {
+		return []string{}
+	}
+
+	var series []int
+	for _, v := range versions {
+		if n, ok := parseMySQLVersion(v.DBVersion); ok && !slices.Contains(series, n.series()) {
+			series = append(series, n.series())
+		}
+	}
+	slices.Sort(series)
+	next := current.series()
+	if i := slices.Index(series, current.series()); i >= 0 && i+1 < len(series) {
+		next = series[i+1]
+	}
+
+	type target struct {
+		code string
+		n    mysqlVersionNumber
+	}
+	var targets []target
+	for _, v := range versions {
+		n, ok := parseMySQLVersion(v.DBVersion)
+		if ok && n.compare(current) > 0 && n.series() <= next {
+			targets = append(targets, target{v.DBVersion, n})
+		}
+	}
+	slices.SortFunc(targets, func(a, b target) int { return a.n.compare(b.n) })
+
+	codes := make([]string, 0, len(targets))
+	for _, t := range targets {
+		codes = append(codes, t.code)
+	}
+	return codes
+}
+
+func listMySQLStorageTypes(ctx context.Context, cfg *config.Config) (ListMySQLStorageTypesOutput, error) {
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return ListMySQLStorageTypesOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	result, err := client.MySQL().ListStorageTypes(ctx)
+	if err != nil {
+		return ListMySQLStorageTypesOutput{}, fmt.Errorf("failed to list storage types: %w", err)
+	}
+	types := append([]string{}, result.StorageTypes...)
+	return ListMySQLStorageTypesOutput{StorageTypes: types, Count: len(types)}, nil
+}
+
+func listMySQLSubnets(ctx context.Context, cfg *config.Config, includeFull bool) (ListMySQLSubnetsOutput, error) {
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return ListMySQLSubnetsOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	result, err := client.MySQL().ListSubnets(ctx)
+	if err != nil {
+		return ListMySQLSubnetsOutput{}, fmt.Errorf("failed to list subnets: %w", err)
+	}
+
+	out := ListMySQLSubnetsOutput{Subnets: []MySQLSubnet{}}
+	for _, s := range result.Subnets {
+		if s.AvailableIpCount <= 0 && !includeFull {
+			out.Hidden++
+			continue
+		}
+		out.Subnets = append(out.Subnets, MySQLSubnet{
+			ID:           s.SubnetID,
+			Name:         s.SubnetName,
+			CIDR:         s.SubnetCidr,
+			HasGateway:   s.UsingGateway,
+			AvailableIPs: s.AvailableIpCount,
+		})
+	}
+	out.Count = len(out.Subnets)
+	return out, nil
+}
+
+// listAvailabilityZones reads the zones from the Compute API; RDS has no
+// endpoint for them and the SDK's compute client has no call for it
+func listAvailabilityZones(ctx context.Context, cfg *config.Config) (ListAvailabilityZonesOutput, error) {
+	region, tenantID, username, password := cfg.ComputeCredentials()
+	if tenantID == "" || username == "" || password == "" {
+		return ListAvailabilityZonesOutput{}, fmt.Errorf("listing availability zones needs tenant ID, username and API password; set them with nhn_set_credential or in the credentials file")
+	}
+	token, err := cfg.IdentityToken(ctx, tenantID)
+	if err != nil {
+		return ListAvailabilityZonesOutput{}, err
+	}
+
+	u := fmt.Sprintf(computeURLFormat, strings.ToLower(region), url.PathEscape(tenantID)) + "/os-availability-zone"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return ListAvailabilityZonesOutput{}, err
+	}
+	req.Header.Set("X-Auth-Token", token)
+
+	resp, err := (&http.Client{Timeout: 60 * time.Second}).Do(req)
+	if err != nil {
+		return ListAvailabilityZonesOutput{}, fmt.Errorf("failed to list availability zones: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return ListAvailabilityZonesOutput{}, fmt.Errorf("failed to list availability zones: HTTP %d", resp.StatusCode)
+	}
+
+	var result struct {
+		AvailabilityZoneInfo []struct {
+			ZoneName  string `json:"zoneName"`
+			ZoneState struct {
+				Available bool `json:"available"`
+			} `json:"zoneState"`
+		} `json:"availabilityZoneInfo"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return ListAvailabilityZonesOutput{}, fmt.Errorf("failed to decode availability zones: %w", err)
+	}
+
+	out := ListAvailabilityZonesOutput{Region: region, Zones: []AvailabilityZone{}}
+	for _, z := range result.AvailabilityZoneInfo {
+		out.Zones = append(out.Zones, AvailabilityZone{Name: z.ZoneName, Available: z.ZoneState.Available})
+	}
+	slices.SortFunc(out.Zones, func(a, b AvailabilityZone) int { return strings.Compare(a.Name, b.Name) })
+	out.Count = len(out.Zones)
+	return out, nil
+}
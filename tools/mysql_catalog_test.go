@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseMySQLVersion(t *testing.T) {
+	tests := []struct {
+		code string
+		want mysqlVersionNumber
+		ok   bool
+	}{
+		{"MYSQL_V8032", mysqlVersionNumber{8, 0, 32}, true},
+		{"MYSQL_V5744", mysqlVersionNumber{5, 7, 44}, true},
+		{"mysql_v8400", mysqlVersionNumber{8, 4, 0}, true},
+		{"MYSQL_V56", mysqlVersionNumber{}, false},
+		{"MYSQL_VX032", mysqlVersionNumber{}, false},
+		{"MYSQL_V80XX", mysqlVersionNumber{}, false},
+		{"MARIADB_V1011", mysqlVersionNumber{}, false},
+		{"", mysqlVersionNumber{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseMySQLVersion(tt.code)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseMySQLVersion(%q) = %v, %v; want %v, %v", tt.code, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestMySQLUpgradeTargets(t *testing.T) {
+	var versions []mysqlVersionRecord
+	for _, code := range []string{"MYSQL_V8400", "MYSQL_V8036", "MYSQL_V8032", "MYSQL_V5744", "MYSQL_V5733", "MYSQL_V5651", "UNKNOWN"} {
+		versions = append(versions, mysqlVersionRecord{DBVersion: code})
+	}
+
+	tests := []struct {
+		from string
+		want []string
+	}{
+		{"MYSQL_V5651", []string{"MYSQL_V5733", "MYSQL_V5744"}},
+		{"MYSQL_V5733", []string{"MYSQL_V5744", "MYSQL_V8032", "MYSQL_V8036"}},
+		{"MYSQL_V5744", []string{"MYSQL_V8032", "MYSQL_V8036"}},
+		{"MYSQL_V8032", []string{"MYSQL_V8036", "MYSQL_V8400"}},
+		{"MYSQL_V8036", []string{"MYSQL_V8400"}},
+		{"MYSQL_V8400", []string{}},
+		// A patch no longer offered is placed in its series
+		{"MYSQL_V8028", []string{"MYSQL_V8032", "MYSQL_V8036", "MYSQL_V8400"}},
+		// A series no longer offered only allows newer patches of itself
+		{"MYSQL_V8100", []string{}},
+		{"UNKNOWN", []string{}},
+		{"", []string{}},
+	}
+	for _, tt := range tests {
+		if got := mysqlUpgradeTargets(tt.from, versions); !slices.Equal(got, tt.want) {
+			t.Errorf("mysqlUpgradeTargets(%q) = %v, want %v", tt.from, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,352 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/rds/mysql"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RDS for MySQL creation limits
+const (
+	mysqlMinStorageGB   = 20
+	mysqlMaxStorageGB   = 2048
+	mysqlDefaultPort    = 3306
+	mysqlMinPort        = 3306
+	mysqlMaxPort        = 43306
+	mysqlMaxBackupDays  = 730
+	mysqlMinPasswordLen = 4
+	mysqlMaxPasswordLen = 16
+)
+
+// Typical time for a new instance to reach AVAILABLE. HA instances also
+// provision a candidate master, which roughly doubles the time.
+const (
+	mysqlCreateMinutes   = 10
+	mysqlCreateHAMinutes = 20
+)
+
+var (
+	mysqlInstanceNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9._-]{0,99}$`)
+	mysqlUserNamePattern     = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,31}$`)
+	mysqlBackupTimePattern   = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]$`)
+)
+
+// mysqlBackupWindowDurations are the accepted backupWndDuration values
+var mysqlBackupWindowDurations = []string{
+	"HALF_AN_HOUR", "ONE_HOUR", "ONE_HOUR_AND_HALF", "TWO_HOURS", "TWO_HOURS_AND_HALF", "THREE_HOURS",
+}
+
+type CreateMySQLInstanceInput struct {
+	Name                 string   `json:"name" jsonschema_description:"Instance name: 1-100 characters, letters, digits, '.', '_' and '-', starting with a letter"`
+	Description          string   `json:"description,omitempty" jsonschema_description:"Instance description (optional)"`
+	Flavor               string   `json:"flavor" jsonschema_description:"Flavor ID or name from nhn_mysql_list_flavors"`
+	DBVersion            string   `json:"db_version" jsonschema_description:"DB version, e.g. MYSQL_V8032"`
+	StorageType          string   `json:"storage_type" jsonschema_description:"Storage type, e.g. General SSD"`
+	StorageSizeGB        int      `json:"storage_size_gb" jsonschema_description:"Storage size in GB (20-2048)"`
+	SubnetID             string   `json:"subnet_id" jsonschema_description:"ID of a subnet usable by RDS"`
+	AvailabilityZone     string   `json:"availability_zone" jsonschema_description:"Availability zone, e.g. kr-pub-a"`
+	ParameterGroupID     string   `json:"parameter_group_id" jsonschema_description:"Parameter group ID; must match db_version"`
+	SecurityGroupIDs     []string `json:"security_group_ids,omitempty" jsonschema_description:"DB security group IDs (optional)"`
+	DBUserName           string   `json:"db_user_name" jsonschema_description:"Administrator user name"`
+	DBPassword           string   `json:"db_password" jsonschema_description:"Administrator password (4-16 characters)"`
+	Port                 int      `json:"port,omitempty" jsonschema_description:"DB port (3306-43306, default 3306)"`
+	UseHighAvailability  bool     `json:"use_high_availability,omitempty" jsonschema_description:"Create a candidate master for high availability"`
+	UsePublicAccess      bool     `json:"use_public_access,omitempty" jsonschema_description:"Assign a public endpoint"`
+	DeletionProtection   bool     `json:"deletion_protection,omitempty" jsonschema_description:"Enable deletion protection"`
+	BackupPeriodDays     *int     `json:"backup_period_days,omitempty" jsonschema_description:"Automatic backup retention in days (0-730, default 1)"`
+	BackupWindowStart    string   `json:"backup_window_start,omitempty" jsonschema_description:"Backup window start time HH:MM:SS (default 00:00:00)"`
+	BackupWindowDuration string   `json:"backup_window_duration,omitempty" jsonschema_description:"Backup window duration: HALF_AN_HOUR, ONE_HOUR, ONE_HOUR_AND_HALF, TWO_HOURS, TWO_HOURS_AND_HALF, THREE_HOURS (default ONE_HOUR)"`
+}
+
+// CreateMySQLInstanceOutput - output for creating a MySQL instance
+type CreateMySQLInstanceOutput struct {
+	InstanceID              string `json:"instance_id,omitempty"`
+	JobID                   string `json:"job_id"`
+	Name                    string `json:"name"`
+	FlavorID                string `json:"flavor_id"`
+	FlavorName              string `json:"flavor_name"`
+	EstimatedMinutesToReady int    `json:"estimated_minutes_to_available"`
+	Note                    string `json:"note,omitempty"`
+}
+
+func registerMySQLCreateTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_create_instance",
+		Description: "Create an NHN Cloud RDS MySQL instance. Validates the flavor, DB version, storage type and size, subnet, parameter group and security groups against the lookup APIs before submitting, and reports every problem at once. Returns the new instance ID and the expected time until it is AVAILABLE.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[CreateMySQLInstanceInput]) (*mcp.CallToolResultFor[CreateMySQLInstanceOutput], error) {
+		out, err := createMySQLInstance(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[CreateMySQLInstanceOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		text := fmt.Sprintf("Creating MySQL instance %s (job %s), expected to be AVAILABLE in about %d minutes", out.Name, out.JobID, out.EstimatedMinutesToReady)
+		if out.InstanceID != "" {
+			text = fmt.Sprintf("Creating MySQL instance %s (%s, job %s), expected to be AVAILABLE in about %d minutes", out.Name, out.InstanceID, out.JobID, out.EstimatedMinutesToReady)
+		}
+		return &mcp.CallToolResultFor[CreateMySQLInstanceOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+// validationErrors collects every problem found before submitting a request
+type validationErrors []string
+
+func (v *validationErrors) addf(format string, args ...any) {
+	*v = append(*v, fmt.Sprintf(format, args...))
+}
+
+func (v validationErrors) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return fmt.Errorf("validation failed:\n- %s", strings.Join(v, "\n- "))
+}
+
+func createMySQLInstance(ctx context.Context, cfg *config.Config, in CreateMySQLInstanceInput) (CreateMySQLInstanceOutput, error) {
+	var problems validationErrors
+
+	// Local checks first, so obvious mistakes don't cost API calls
+	if !mysqlInstanceNamePattern.MatchString(in.Name) {
+		problems.addf("name %q must be 1-100 characters of letters, digits, '.', '_' or '-', starting with a letter", in.Name)
+	}
+	if !mysqlUserNamePattern.MatchString(in.DBUserName) {
+		problems.addf("db_user_name %q must be 1-32 characters of letters, digits or '_', starting with a letter", in.DBUserName)
+	}
+	if n := len(in.DBPassword); n < mysqlMinPasswordLen || n > mysqlMaxPasswordLen {
+		problems.addf("db_password must be %d-%d characters", mysqlMinPasswordLen, mysqlMaxPasswordLen)
+	}
+	if in.StorageSizeGB < mysqlMinStorageGB || in.StorageSizeGB > mysqlMaxStorageGB {
+		problems.addf("storage_size_gb %d must be between %d and %d", in.StorageSizeGB, mysqlMinStorageGB, mysqlMaxStorageGB)
+	}
+	port := in.Port
+	if port == 0 {
+		port = mysqlDefaultPort
+	}
+	if port < mysqlMinPort || port > mysqlMaxPort {
+		problems.addf("port %d must be between %d and %d", port, mysqlMinPort, mysqlMaxPort)
+	}
+	if in.AvailabilityZone == "" {
+		problems.addf("availability_zone is required")
+	}
+
+	backupPeriod := 1
+	if in.BackupPeriodDays != nil {
+		backupPeriod = *in.BackupPeriodDays
+	}
+	if backupPeriod < 0 || backupPeriod > mysqlMaxBackupDays {
+		problems.addf("backup_period_days %d must be between 0 and %d", backupPeriod, mysqlMaxBackupDays)
+	}
+	windowStart := in.BackupWindowStart
+	if windowStart == "" {
+		windowStart = "00:00:00"
+	}
+	if !mysqlBackupTimePattern.MatchString(windowStart) {
+		problems.addf("backup_window_start %q must be HH:MM:SS", windowStart)
+	}
+	windowDuration := in.BackupWindowDuration
+	if windowDuration == "" {
+		windowDuration = "ONE_HOUR"
+	}
+	if !slices.Contains(mysqlBackupWindowDurations, windowDuration) {
+		problems.addf("backup_window_duration %q must be one of %s", windowDuration, strings.Join(mysqlBackupWindowDurations, ", "))
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return CreateMySQLInstanceOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	rds := client.MySQL()
+
+	// Reference checks against the lookup APIs
+	flavor, err := resolveMySQLFlavor(ctx, rds, in.Flavor)
+	if err != nil {
+		problems.addf("%v", err)
+	}
+
+	versions, err := rds.ListVersions(ctx)
+	if err != nil {
+		return CreateMySQLInstanceOutput{}, fmt.Errorf("failed to list DB versions: %w", err)
+	}
+	var versionNames []string
+	versionOK := false
+	for _, v := range versions.DBVersions {
+		versionNames = append(versionNames, v.DBVersion)
+		if v.DBVersion == in.DBVersion {
+			versionOK = true
+		}
+	}
+	if !versionOK {
+		problems.addf("db_version %q is not supported; available: %s", in.DBVersion, strings.Join(versionNames, ", "))
+	}
+
+	storageTypes, err := rds.ListStorageTypes(ctx)
+	if err != nil {
+		return CreateMySQLInstanceOutput{}, fmt.Errorf("failed to list storage types: %w", err)
+	}
+	if !slices.Contains(storageTypes.StorageTypes, in.StorageType) {
+		problems.addf("storage_type %q is not available; available: %s", in.StorageType, strings.Join(storageTypes.StorageTypes, ", "))
+	}
+
+	subnets, err := rds.ListSubnets(ctx)
+	if err != nil {
+		return CreateMySQLInstanceOutput{}, fmt.Errorf("failed to list subnets: %w", err)
+	}
+	subnetOK := false
+	for _, s := range subnets.Subnets {
+		if s.SubnetID == in.SubnetID {
+			subnetOK = true
+			if s.AvailableIpCount == 0 {
+				problems.addf("subnet %s (%s) has no available IP addresses", s.SubnetName, s.SubnetID)
+			}
+		}
+	}
+	if !subnetOK {
+		problems.addf("subnet_id %q is not a subnet usable by RDS", in.SubnetID)
+	}
+
+	// The SDK's dbVersion filter is not applied reliably, so filter here
+	groups, err := rds.ListParameterGroups(ctx, "")
+	if err != nil {
+		return CreateMySQLInstanceOutput{}, fmt.Errorf("failed to list parameter groups: %w", err)
+	}
+	groupOK := false
+	for _, g := range groups.ParameterGroups {
+		if g.ParameterGroupID != in.ParameterGroupID {
+			continue
+		}
+		groupOK = true
+		if g.DBVersion != in.DBVersion {
+			problems.addf("parameter group %s is for %s, not %s", g.ParameterGroupName, g.DBVersion, in.DBVersion)
+		}
+	}
+	if !groupOK {
+		problems.addf("parameter_group_id %q not found", in.ParameterGroupID)
+	}
+
+	if len(in.SecurityGroupIDs) > 0 {
+		sgs, err := rds.ListSecurityGroups(ctx)
+		if err != nil {
+			return CreateMySQLInstanceOutput{}, fmt.Errorf("failed to list DB security groups: %w", err)
+		}
+		known := make(map[string]bool, len(sgs.DBSecurityGroups))
+		for _, g := range sgs.DBSecurityGroups {
+			known[g.DBSecurityGroupID] = true
+		}
+		for _, id := range in.SecurityGroupIDs {
+			if !known[id] {
+				problems.addf("security group %q not found", id)
+			}
+		}
+	}
+
+	instances, err := rds.ListInstances(ctx)
+	if err != nil {
+		return CreateMySQLInstanceOutput{}, fmt.Errorf("failed to list instances: %w", err)
+	}
+	for _, inst := range instances.DBInstances {
+		if inst.DBInstanceName == in.Name {
+			problems.addf("an instance named %q already exists (%s)", in.Name, inst.DBInstanceID)
+		}
+	}
+
+	if err := problems.err(); err != nil {
+		return CreateMySQLInstanceOutput{}, err
+	}
+
+	req := &mysql.CreateInstanceInput{
+		DBInstanceName:     in.Name,
+		Description:        in.Description,
+		DBFlavorID:         flavor.FlavorID,
+		DBVersion:          in.DBVersion,
+		DBUserName:         in.DBUserName,
+		DBPassword:         in.DBPassword,
+		DBPort:             port,
+		ParameterGroupID:   in.ParameterGroupID,
+		DBSecurityGroupIDs: in.SecurityGroupIDs,
+		Network: &mysql.Network{
+			SubnetID:         in.SubnetID,
+			AvailabilityZone: in.AvailabilityZone,
+			UsePublicAccess:  in.UsePublicAccess,
+		},
+		Storage: &mysql.Storage{
+			StorageType: in.StorageType,
+			StorageSize: in.StorageSizeGB,
+		},
+		Backup: &mysql.BackupConfig{
+			BackupPeriod: backupPeriod,
+			BackupSchedules: []mysql.BackupSchedule{{
+				BackupWndBgnTime:  windowStart,
+				BackupWndDuration: windowDuration,
+			}},
+		},
+		UseHighAvailability:   in.UseHighAvailability,
+		UseDeletionProtection: in.DeletionProtection,
+	}
+
+	result, err := rds.CreateInstance(ctx, req)
+	if err != nil {
+		return CreateMySQLInstanceOutput{}, fmt.Errorf("failed to create instance: %w", err)
+	}
+
+	out := CreateMySQLInstanceOutput{
+		JobID:                   result.JobID,
+		Name:                    in.Name,
+		FlavorID:                flavor.FlavorID,
+		FlavorName:              flavor.FlavorName,
+		EstimatedMinutesToReady: mysqlCreateMinutes,
+	}
+	if in.UseHighAvailability {
+		out.EstimatedMinutesToReady = mysqlCreateHAMinutes
+	}
+
+	// The create API only returns a job ID; the instance appears in the
+	// list right away under its (unique) name.
+	if id, err := findMySQLInstanceIDByName(ctx, rds, in.Name); err == nil && id != "" {
+		out.InstanceID = id
+	} else {
+		out.Note = fmt.Sprintf("instance ID not yet visible; find %q with nhn_mysql_list_instances", in.Name)
+	}
+
+	return out, nil
+}
+
+// resolveMySQLFlavor finds a flavor by ID or name
+func resolveMySQLFlavor(ctx context.Context, rds *mysql.Client, flavor string) (mysql.DBFlavor, error) {
+	flavors, err := rds.ListFlavors(ctx)
+	if err != nil {
+		return mysql.DBFlavor{}, fmt.Errorf("failed to list flavors: %w", err)
+	}
+
+	var names []string
+	for _, f := range flavors.DBFlavors {
+		if f.FlavorID == flavor || f.FlavorName == flavor {
+			return f, nil
+		}
+		names = append(names, f.FlavorName)
+	}
+	return mysql.DBFlavor{}, fmt.Errorf("flavor %q not found; available: %s", flavor, strings.Join(names, ", "))
+}
+
+func findMySQLInstanceIDByName(ctx context.Context, rds *mysql.Client, name string) (string, error) {
+	instances, err := rds.ListInstances(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, inst := range instances.DBInstances {
+		if inst.DBInstanceName == name {
+			return inst.DBInstanceID, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,246 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/rds/mysql"
+)
+
+// MySQLEndpoint is an address clients connect to
+type MySQLEndpoint struct {
+	Type      string `json:"type"`
+	Domain    string `json:"domain,omitempty"`
+	IPAddress string `json:"ip_address,omitempty"`
+}
+
+// MySQLInstanceNetwork is where an instance lives and how to reach it
+type MySQLInstanceNetwork struct {
+	AvailabilityZone string          `json:"availability_zone,omitempty"`
+	SubnetID         string          `json:"subnet_id"`
+	SubnetName       string          `json:"subnet_name,omitempty"`
+	SubnetCIDR       string          `json:"subnet_cidr,omitempty"`
+	Port             int             `json:"port"`
+	Endpoints        []MySQLEndpoint `json:"endpoints"`
+}
+
+// MySQLInstanceHA is the high availability state of an instance
+type MySQLInstanceHA struct {
+	Enabled             bool `json:"enabled"`
+	Paused              bool `json:"paused"`
+	PingIntervalSeconds int  `json:"ping_interval_seconds,omitempty"`
+}
+
+// MySQLBackupWindow is a daily window automatic backups start in
+type MySQLBackupWindow struct {
+	Start    string `json:"start"`
+	Duration string `json:"duration"`
+}
+
+// MySQLInstanceBackup is the automatic backup configuration of an instance
+type MySQLInstanceBackup struct {
+	RetentionDays     int                 `json:"retention_days"`
+	RetryCount        int                 `json:"retry_count"`
+	UseTableLock      bool                `json:"use_table_lock"`
+	ReplicationRegion string              `json:"replication_region,omitempty"`
+	Windows           []MySQLBackupWindow `json:"windows"`
+}
+
+// MySQLGroupRef identifies a parameter or security group
+type MySQLGroupRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// MySQLInstanceDetail is the full description of an instance. The first
+// fields match MySQLInstance so both read the same.
+type MySQLInstanceDetail struct {
+	ID                   string               `json:"id"`
+	Name                 string               `json:"name"`
+	Status               string               `json:"status"`
+	Version              string               `json:"version"`
+	StorageType          string               `json:"storage_type"`
+	StorageSize          int                  `json:"storage_size_gb"`
+	Role                 string               `json:"role"`
+	GroupID              string               `json:"group_id"`
+	Description          string               `json:"description,omitempty"`
+	ProgressStatus       string               `json:"progress_status,omitempty"`
+	Flavor               MySQLFlavor          `json:"flavor"`
+	HA                   *MySQLInstanceHA     `json:"high_availability,omitempty"`
+	Network              MySQLInstanceNetwork `json:"network"`
+	ParameterGroup       MySQLGroupRef        `json:"parameter_group"`
+	SecurityGroups       []MySQLGroupRef      `json:"security_groups"`
+	Backup               *MySQLInstanceBackup `json:"backup,omitempty"`
+	DeletionProtection   bool                 `json:"deletion_protection"`
+	AuthenticationPlugin string               `json:"authentication_plugin,omitempty"`
+	TLSOption            string               `json:"tls_option,omitempty"`
+	Created              string               `json:"created"`
+	Updated              string               `json:"updated"`
+	Notes                []string             `json:"notes,omitempty"`
+}
+
+// GetMySQLInstanceDetailOutput - output for getting a single MySQL instance in detail
+type GetMySQLInstanceDetailOutput struct {
+	Instance MySQLInstanceDetail `json:"instance"`
+}
+
+func formatMySQLInstanceDetail(d MySQLInstanceDetail) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Instance: %s (%s) %s, %s", d.Name, d.ID, d.Status, d.Version)
+	if d.Role != "" {
+		fmt.Fprintf(&b, ", %s", d.Role)
+	}
+	fmt.Fprintf(&b, "\nFlavor: %s (%d vCPU, %d MB RAM); storage %s %d GB", d.Flavor.Name, d.Flavor.VCPUs, d.Flavor.RAM, d.StorageType, d.StorageSize)
+	for _, e := range d.Network.Endpoints {
+		addr := e.Domain
+		if addr == "" {
+			addr = e.IPAddress
+		}
+		fmt.Fprintf(&b, "\nEndpoint %s: %s:%d", e.Type, addr, d.Network.Port)
+	}
+	if d.HA != nil {
+		fmt.Fprintf(&b, "\nHigh availability: %s", onOff(d.HA.Enabled))
+		if d.HA.Paused {
+			b.WriteString(" (paused)")
+		}
+	}
+	if d.Backup != nil {
+		fmt.Fprintf(&b, "\nBackups: kept %d days", d.Backup.RetentionDays)
+		for _, w := range d.Backup.Windows {
+			fmt.Fprintf(&b, ", window %s for %s", w.Start, w.Duration)
+		}
+	}
+	fmt.Fprintf(&b, "\nDeletion protection: %s", onOff(d.DeletionProtection))
+	for _, n := range d.Notes {
+		b.WriteString("\nNote: " + n)
+	}
+	return b.String()
+}
+
+func onOff(v bool) string {
+	if v {
+		return "on"
+	}
+	return "off"
+}
+
+// getMySQLInstanceDetail collects the instance and the settings kept behind
+// separate endpoints. A failed secondary lookup becomes a note rather than
+// failing the whole call.
+func getMySQLInstanceDetail(ctx context.Context, cfg *config.Config, instanceID string) (GetMySQLInstanceDetailOutput, error) {
+	if instanceID == "" {
+		return GetMySQLInstanceDetailOutput{}, fmt.Errorf("instance_id is required")
+	}
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return GetMySQLInstanceDetailOutput{}, err
+	}
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return GetMySQLInstanceDetailOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	rds := client.MySQL()
+
+	var rec struct {
+		mysqlInstanceRecord
+		ProgressStatus string `json:"progressStatus"`
+	}
+	if err := api.get(ctx, "/db-instances/"+url.PathEscape(instanceID), nil, &rec); err != nil {
+		return GetMySQLInstanceDetailOutput{}, fmt.Errorf("failed to get instance: %w", err)
+	}
+
+	d := MySQLInstanceDetail{
+		ID:                   rec.DBInstanceID,
+		Name:                 rec.DBInstanceName,
+		Status:               rec.DBInstanceStatus,
+		Version:              rec.DBVersion,
+		StorageType:          rec.StorageType,
+		StorageSize:          rec.StorageSize,
+		Role:                 rec.DBInstanceType,
+		GroupID:              rec.DBInstanceGroupID,
+		Description:          rec.Description,
+		ProgressStatus:       rec.ProgressStatus,
+		Flavor:               MySQLFlavor{ID: rec.DBFlavorID},
+		Network:              MySQLInstanceNetwork{SubnetID: rec.SubnetID, Port: rec.DBPort, Endpoints: []MySQLEndpoint{}},
+		ParameterGroup:       MySQLGroupRef{ID: rec.ParameterGroupID},
+		SecurityGroups:       []MySQLGroupRef{},
+		DeletionProtection:   rec.UseDeletionProtection,
+		AuthenticationPlugin: rec.AuthenticationPlugin,
+		TLSOption:            rec.TLSOption,
+		Created:              rec.CreatedYmdt,
+		Updated:              rec.UpdatedYmdt,
+	}
+	note := func(what string, err error) {
+		d.Notes = append(d.Notes, fmt.Sprintf("%s unavailable: %v", what, err))
+	}
+
+	if flavor, err := resolveMySQLFlavor(ctx, rds, rec.DBFlavorID); err != nil {
+		note("flavor", err)
+	} else {
+		d.Flavor = MySQLFlavor{ID: flavor.FlavorID, Name: flavor.FlavorName, VCPUs: flavor.Vcpus, RAM: flavor.Ram}
+	}
+
+	if network, err := rds.GetNetworkInfo(ctx, instanceID); err != nil {
+		note("network", err)
+	} else {
+		d.Network.AvailabilityZone = network.AvailabilityZone
+		d.Network.SubnetName = network.Subnet.SubnetName
+		d.Network.SubnetCIDR = network.Subnet.SubnetCidr
+		if network.Subnet.SubnetID != "" {
+			d.Network.SubnetID = network.Subnet.SubnetID
+		}
+		for _, e := range network.EndPoints {
+			d.Network.Endpoints = append(d.Network.Endpoints, MySQLEndpoint{Type: e.EndPointType, Domain: e.Domain, IPAddress: e.IPAddress})
+		}
+	}
+
+	// Only a master carries HA settings; candidates and replicas follow it
+	if rec.DBInstanceType == mysqlRoleMaster {
+		var ha mysqlHAInfo
+		if err := api.get(ctx, "/db-instances/"+url.PathEscape(instanceID)+"/high-availability", nil, &ha); err != nil {
+			note("high availability", err)
+		} else {
+			d.HA = &MySQLInstanceHA{
+				Enabled:             ha.UseHighAvailability,
+				Paused:              strings.Contains(strings.ToUpper(rec.ProgressStatus), mysqlHAProgressPaused),
+				PingIntervalSeconds: ha.PingInterval,
+			}
+		}
+	}
+
+	if backup, err := fetchMySQLBackupInfo(ctx, api, instanceID); err != nil {
+		note("backup settings", err)
+	} else {
+		settings := newMySQLInstanceBackup(backup)
+		d.Backup = &settings
+	}
+
+	if rec.ParameterGroupID != "" {
+		if group, err := rds.GetParameterGroup(ctx, rec.ParameterGroupID); err != nil {
+			note("parameter group", err)
+		} else {
+			d.ParameterGroup.Name = group.ParameterGroupName
+		}
+	}
+
+	for _, id := range rec.DBSecurityGroupIDs {
+		d.SecurityGroups = append(d.SecurityGroups, MySQLGroupRef{ID: id})
+	}
+	if len(rec.DBSecurityGroupIDs) > 0 {
+		if groups, err := rds.ListSecurityGroups(ctx); err != nil {
+			note("security group names", err)
+		} else {
+			for i, ref := range d.SecurityGroups {
+				if j := slices.IndexFunc(groups.DBSecurityGroups, func(g mysql.DBSecurityGroup) bool { return g.DBSecurityGroupID == ref.ID }); j >= 0 {
+					d.SecurityGroups[i].Name = groups.DBSecurityGroups[j].DBSecurityGroupName
+				}
+			}
+		}
+	}
+
+	return GetMySQLInstanceDetailOutput{Instance: d}, nil
+}
@@ -0,0 +1,247 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	mysqlEventPageSize     = 100
+	mysqlEventMaxPages     = 20
+	mysqlEventDefaultHours = 24
+	mysqlEventMaxHours     = 90 * 24
+)
+
+// mysqlEventKind groups events by keywords in their code or message
+type mysqlEventKind struct {
+	kind     string
+	keywords []string
+}
+
+// mysqlEventKinds are tried in order; the first match wins, so RESTART is
+// never counted as start_stop
+var mysqlEventKinds = []mysqlEventKind{
+	{"failover", []string{"FAILOVER"}},
+	{"restart", []string{"RESTART", "REBOOT"}},
+	{"backup", []string{"BACKUP", "RESTORE"}},
+	{"parameter", []string{"PARAMETER"}},
+	{"replication", []string{"REPLICATION", "REPLICA"}},
+	{"storage", []string{"STORAGE", "DISK"}},
+	{"start_stop", []string{"START", "STOP", "SHUTDOWN"}},
+}
+
+// mysqlEvent is one entry of GET /events
+type mysqlEvent struct {
+	EventCategoryType string `json:"eventCategoryType"`
+	SourceID          string `json:"sourceId"`
+	SourceName        string `json:"sourceName"`
+	EventCode         string `json:"eventCode"`
+	EventMessage      string `json:"eventMessage"`
+	EventYmdt         string `json:"eventYmdt"`
+}
+
+// kind returns the mysqlEventKinds entry the event belongs to, or "other"
+func (e mysqlEvent) kind() string {
+	text := strings.ToUpper(e.EventCode + " " + e.EventMessage)
+	for _, k := range mysqlEventKinds {
+		for _, keyword := range k.keywords {
+			if strings.Contains(text, keyword) {
+				return k.kind
+			}
+		}
+	}
+	return "other"
+}
+
+type ListMySQLEventsInput struct {
+	InstanceID string   `json:"instance_id,omitempty" jsonschema_description:"Only events of this instance (optional)"`
+	From       string   `json:"from,omitempty" jsonschema_description:"Start of the window, RFC 3339 (default: hours before to)"`
+	To         string   `json:"to,omitempty" jsonschema_description:"End of the window, RFC 3339 (default: now)"`
+	Hours      int      `json:"hours,omitempty" jsonschema_description:"Window length when from is not set (default 24, max 2160)"`
+	Kinds      []string `json:"kinds,omitempty" jsonschema_description:"Only these kinds: failover, restart, backup, parameter, replication, storage, start_stop, other (optional)"`
+}
+
+// MySQLEvent is one entry of the event history
+type MySQLEvent struct {
+	Time         string `json:"time"`
+	Kind         string `json:"kind"`
+	Category     string `json:"category"`
+	InstanceID   string `json:"instance_id"`
+	InstanceName string `json:"instance_name,omitempty"`
+	Code         string `json:"code"`
+	Message      string `json:"message,omitempty"`
+}
+
+// ListMySQLEventsOutput - output for listing events
+type ListMySQLEventsOutput struct {
+	From   string         `json:"from"`
+	To     string         `json:"to"`
+	Events []MySQLEvent   `json:"events"`
+	Count  int            `json:"count"`
+	ByKind map[string]int `json:"by_kind"`
+	// TotalEvents is the number of events in the window before any kind
+	// filter; Truncated is set when not all of them could be read
+	TotalEvents int  `json:"total_events"`
+	Truncated   bool `json:"truncated"`
+}
+
+func registerMySQLEventTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_list_events",
+		Description: "List NHN Cloud RDS MySQL events (restarts, failovers, backups, parameter changes and more) over a time window, optionally for one instance and only some kinds of event.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLEventsInput]) (*mcp.CallToolResultFor[ListMySQLEventsOutput], error) {
+		out, err := listMySQLEvents(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[ListMySQLEventsOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		text := fmt.Sprintf("Found %d events from %s to %s", out.Count, out.From, out.To)
+		for _, k := range mysqlEventKinds {
+			if n := out.ByKind[k.kind]; n > 0 {
+				text += fmt.Sprintf("\n%s: %d", k.kind, n)
+			}
+		}
+		if n := out.ByKind["other"]; n > 0 {
+			text += fmt.Sprintf("\nother: %d", n)
+		}
+		if out.Truncated {
+			text += "\n" + mysqlEventsTruncatedNote(out.TotalEvents)
+		}
+		return &mcp.CallToolResultFor[ListMySQLEventsOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func listMySQLEvents(ctx context.Context, cfg *config.Config, in ListMySQLEventsInput) (ListMySQLEventsOutput, error) {
+	var problems validationErrors
+	to := time.Now()
+	if in.To != "" {
+		t, err := time.Parse(time.RFC3339, in.To)
+		if err != nil {
+			problems.addf("to %q is not an RFC 3339 time", in.To)
+		} else {
+			to = t
+		}
+	}
+	hours := in.Hours
+	if hours == 0 {
+		hours = mysqlEventDefaultHours
+	}
+	if hours < 1 || hours > mysqlEventMaxHours {
+		problems.addf("hours %d must be between 1 and %d", in.Hours, mysqlEventMaxHours)
+	}
+	from := to.Add(-time.Duration(hours) * time.Hour)
+	if in.From != "" {
+		t, err := time.Parse(time.RFC3339, in.From)
+		if err != nil {
+			problems.addf("from %q is not an RFC 3339 time", in.From)
+		} else {
+			from = t
+		}
+	}
+	if !from.Before(to) {
+		problems.addf("from must be before to")
+	}
+	kinds := make(map[string]bool, len(in.Kinds))
+	for _, k := range in.Kinds {
+		k = strings.ToLower(k)
+		if k != "other" && !slices.ContainsFunc(mysqlEventKinds, func(ek mysqlEventKind) bool { return ek.kind == k }) {
+			problems.addf("unknown kind %q", k)
+		}
+		kinds[k] = true
+	}
+	if err := problems.err(); err != nil {
+		return ListMySQLEventsOutput{}, err
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return ListMySQLEventsOutput{}, err
+	}
+	events, total, err := fetchMySQLEvents(ctx, api, from, to, in.InstanceID)
+	if err != nil {
+		return ListMySQLEventsOutput{}, err
+	}
+
+	out := ListMySQLEventsOutput{
+		From:        from.Format(time.RFC3339),
+		To:          to.Format(time.RFC3339),
+		Events:      []MySQLEvent{},
+		ByKind:      map[string]int{},
+		TotalEvents: total,
+		Truncated:   len(events) < total,
+	}
+	for _, e := range events {
+		// The API may ignore sourceId on some versions, so filter here too
+		if in.InstanceID != "" && e.SourceID != "" && e.SourceID != in.InstanceID {
+			continue
+		}
+		kind := e.kind()
+		if len(kinds) > 0 && !kinds[kind] {
+			continue
+		}
+		out.Events = append(out.Events, MySQLEvent{
+			Time:         e.EventYmdt,
+			Kind:         kind,
+			Category:     e.EventCategoryType,
+			InstanceID:   e.SourceID,
+			InstanceName: e.SourceName,
+			Code:         e.EventCode,
+			Message:      e.EventMessage,
+		})
+		out.ByKind[kind]++
+	}
+	out.Count = len(out.Events)
+	return out, nil
+}
+
+// mysqlEventsTruncatedNote explains a window with more events than were read
+func mysqlEventsTruncatedNote(total int) string {
+	return fmt.Sprintf("Truncated: only the first %d of %d events in the window were read; narrow the window to see the rest", mysqlEventMaxPages*mysqlEventPageSize, total)
+}
+
+// fetchMySQLEvents reads the event history between from and to, optionally
+// for one source, following pages up to mysqlEventMaxPages. It also returns
+// the total the API reports, which exceeds len(events) when pages were left.
+func fetchMySQLEvents(ctx context.Context, api *mysqlAPI, from, to time.Time, sourceID string) ([]mysqlEvent, int, error) {
+	var all []mysqlEvent
+	total := 0
+	for page := 1; page <= mysqlEventMaxPages; page++ {
+		query := url.Values{
+			"from": {from.Format(time.RFC3339)},
+			"to":   {to.Format(time.RFC3339)},
+			"page": {strconv.Itoa(page)},
+			"size": {strconv.Itoa(mysqlEventPageSize)},
+		}
+		if sourceID != "" {
+			query.Set("sourceId", sourceID)
+		}
+
+		var result struct {
+			TotalCounts int          `json:"totalCounts"`
+			Events      []mysqlEvent `json:"events"`
+		}
+		if err := api.get(ctx, "/events", query, &result); err != nil {
+			return nil, 0, fmt.Errorf("failed to list events: %w", err)
+		}
+		all = append(all, result.Events...)
+		total = result.TotalCounts
+		if len(result.Events) < mysqlEventPageSize || len(all) >= result.TotalCounts {
+			break
+		}
+	}
+	return all, max(total, len(all)), nil
+}
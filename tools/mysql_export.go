@@ -0,0 +1,217 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/rds/mysql"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	mysqlExportDefaultTimeout = 60 * time.Minute
+	mysqlExportMaxTimeout     = 6 * time.Hour
+)
+
+type ExportMySQLBackupInput struct {
+	BackupID       string `json:"backup_id,omitempty" jsonschema_description:"ID of an existing backup to export. Set either backup_id or instance_id."`
+	InstanceID     string `json:"instance_id,omitempty" jsonschema_description:"ID of an instance to take a fresh backup of and export. Set either backup_id or instance_id."`
+	Container      string `json:"container" jsonschema_description:"Object Storage container to export into; it must already exist"`
+	ObjectPath     string `json:"object_path" jsonschema_description:"Path inside the container under which the backup files are written"`
+	TimeoutMinutes int    `json:"timeout_minutes,omitempty" jsonschema_description:"How long to wait for the export to finish (default 60, max 360). On timeout the job keeps running; check it with nhn_mysql_get_job."`
+}
+
+// MySQLExportedObject is one file written by a backup export
+type MySQLExportedObject struct {
+	Name  string `json:"name"`
+	Bytes int64  `json:"bytes"`
+}
+
+// ExportMySQLBackupOutput - output for exporting a backup to Object Storage
+type ExportMySQLBackupOutput struct {
+	JobID      string                `json:"job_id"`
+	JobStatus  string                `json:"job_status"`
+	Completed  bool                  `json:"completed"`
+	BackupID   string                `json:"backup_id,omitempty"`
+	InstanceID string                `json:"instance_id,omitempty"`
+	Container  string                `json:"container"`
+	ObjectPath string                `json:"object_path"`
+	Location   string                `json:"location"`
+	Objects    []MySQLExportedObject `json:"objects,omitempty"`
+	TotalBytes int64                 `json:"total_bytes"`
+	Note       string                `json:"note,omitempty"`
+}
+
+func registerMySQLExportTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_export_backup",
+		Description: "Export an NHN Cloud RDS MySQL backup to an Object Storage container, or take a fresh backup of an instance and export it. Waits for the export job to finish, sending a progress notification with the job ID on every job status change, and returns the object location, the exported files and their total size. Needs the API user credentials (username, API password, tenant ID) in addition to the RDS credentials.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ExportMySQLBackupInput]) (*mcp.CallToolResultFor[ExportMySQLBackupOutput], error) {
+		notify := func(step int, job mysqlJob, elapsed time.Duration) {
+			token := params.GetProgressToken()
+			if token == nil {
+				return
+			}
+			_ = ss.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+				ProgressToken: token,
+				Progress:      float64(step),
+				Message:       fmt.Sprintf("export job %s %s after %ds", job.JobID, job.JobStatus, int(elapsed.Seconds())),
+			})
+		}
+
+		out, err := exportMySQLBackup(ctx, cfg, params.Arguments, notify)
+		if err != nil {
+			return &mcp.CallToolResultFor[ExportMySQLBackupOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		text := fmt.Sprintf("Exported %d objects (%d bytes) to %s", len(out.Objects), out.TotalBytes, out.Location)
+		if !out.Completed {
+			text = fmt.Sprintf("Export job %s is still %s: %s", out.JobID, out.JobStatus, out.Note)
+		}
+		return &mcp.CallToolResultFor[ExportMySQLBackupOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func exportMySQLBackup(ctx context.Context, cfg *config.Config, in ExportMySQLBackupInput, notify func(step int, job mysqlJob, elapsed time.Duration)) (ExportMySQLBackupOutput, error) {
+	var problems validationErrors
+	if (in.BackupID == "") == (in.InstanceID == "") {
+		problems.addf("set exactly one of backup_id and instance_id")
+	}
+	if in.Container == "" {
+		problems.addf("container is required")
+	}
+	objectPath := strings.Trim(in.ObjectPath, "/")
+	if objectPath == "" {
+		problems.addf("object_path is required")
+	}
+	timeout := mysqlExportDefaultTimeout
+	if in.TimeoutMinutes != 0 {
+		timeout = time.Duration(in.TimeoutMinutes) * time.Minute
+	}
+	if timeout <= 0 || timeout > mysqlExportMaxTimeout {
+		problems.addf("timeout_minutes %d must be between 1 and %d", in.TimeoutMinutes, int(mysqlExportMaxTimeout.Minutes()))
+	}
+	if err := problems.err(); err != nil {
+		return ExportMySQLBackupOutput{}, err
+	}
+
+	_, tenantID, username, password := cfg.ObjectStorageCredentials()
+	if tenantID == "" || username == "" || password == "" {
+		return ExportMySQLBackupOutput{}, fmt.Errorf("exporting to Object Storage needs tenant ID, username and API password; set them with nhn_set_credential or in the credentials file")
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return ExportMySQLBackupOutput{}, err
+	}
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return ExportMySQLBackupOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	rds := client.MySQL()
+
+	// Listing the container checks that it exists and that the credentials work
+	// before a long-running job is started. The listing is also kept so that
+	// only files written by this export are reported.
+	existing, err := listObjectStorageObjects(ctx, cfg, in.Container, objectPath+"/")
+	if err != nil {
+		return ExportMySQLBackupOutput{}, err
+	}
+	before := make(map[string]string, len(existing))
+	for _, o := range existing {
+		before[o.Name] = o.Hash
+	}
+
+	out := ExportMySQLBackupOutput{
+		BackupID:   in.BackupID,
+		InstanceID: in.InstanceID,
+		Container:  in.Container,
+		ObjectPath: objectPath,
+		Location:   objectStorageURL(cfg, in.Container, objectPath),
+	}
+
+	var result *mysql.JobOutput
+	if in.BackupID != "" {
+		backup, err := findMySQLBackup(ctx, api, in.BackupID)
+		if err != nil {
+			return ExportMySQLBackupOutput{}, err
+		}
+		if backup.BackupStatus != mysqlBackupCompleted {
+			return ExportMySQLBackupOutput{}, fmt.Errorf("backup %s is %s; only %s backups can be exported", in.BackupID, backup.BackupStatus, mysqlBackupCompleted)
+		}
+		out.InstanceID = backup.DBInstanceID
+
+		result, err = rds.ExportBackup(ctx, in.BackupID, &mysql.ExportBackupInput{
+			TenantID:        tenantID,
+			Username:        username,
+			Password:        password,
+			TargetContainer: in.Container,
+			ObjectPath:      objectPath,
+		})
+		if err != nil {
+			return ExportMySQLBackupOutput{}, fmt.Errorf("failed to export backup: %w", err)
+		}
+	} else {
+		inst, err := getMySQLInstance(ctx, cfg, in.InstanceID)
+		if err != nil {
+			return ExportMySQLBackupOutput{}, err
+		}
+		if inst.Instance.Status != mysqlStatusAvailable {
+			return ExportMySQLBackupOutput{}, fmt.Errorf("cannot back up instance %s (%s): status is %s, expected %s",
+				inst.Instance.Name, in.InstanceID, inst.Instance.Status, mysqlStatusAvailable)
+		}
+
+		result, err = rds.BackupToObjectStorage(ctx, in.InstanceID, &mysql.BackupToObjectStorageInput{
+			TenantID:        tenantID,
+			Username:        username,
+			Password:        password,
+			TargetContainer: in.Container,
+			ObjectPath:      objectPath,
+		})
+		if err != nil {
+			return ExportMySQLBackupOutput{}, fmt.Errorf("failed to back up to Object Storage: %w", err)
+		}
+	}
+	out.JobID = result.JobID
+
+	start, step := time.Now(), 0
+	job, err := waitForMySQLJob(ctx, api, result.JobID, timeout, func(job mysqlJob) {
+		step++
+		notify(step, job, time.Since(start))
+	})
+	if err != nil {
+		return ExportMySQLBackupOutput{}, fmt.Errorf("export job %s started but could not be tracked: %w", result.JobID, err)
+	}
+	out.JobStatus = job.JobStatus
+	switch {
+	case job.failed():
+		return ExportMySQLBackupOutput{}, fmt.Errorf("export job %s ended with status %s", job.JobID, job.JobStatus)
+	case !job.succeeded():
+		out.Note = fmt.Sprintf("not finished after %s; check it with nhn_mysql_get_job", timeout)
+		return out, nil
+	}
+	out.Completed = true
+
+	objects, err := listObjectStorageObjects(ctx, cfg, in.Container, objectPath+"/")
+	if err != nil {
+		out.Note = fmt.Sprintf("export finished, but listing the exported files failed: %v", err)
+		return out, nil
+	}
+	for _, o := range objects {
+		if hash, ok := before[o.Name]; ok && hash == o.Hash {
+			continue
+		}
+		out.Objects = append(out.Objects, MySQLExportedObject{Name: o.Name, Bytes: o.Bytes})
+		out.TotalBytes += o.Bytes
+	}
+	return out, nil
+}
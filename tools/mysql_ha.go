@@ -0,0 +1,458 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/rds/mysql"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	mysqlHAMinPingInterval   = 1
+	mysqlHAMaxPingInterval   = 600
+	mysqlFailoverDefaultDays = 30
+	mysqlFailoverMaxDays     = 90
+	mysqlHAProgressPaused    = "PAUSE"
+)
+
+// Expected impact of each HA operation, shown before and after it is requested
+const (
+	mysqlHAEnableImpact   = "No downtime. A candidate master is built in another availability zone from a fresh backup, which takes 10-20 minutes and adds I/O load to the master."
+	mysqlHADisableImpact  = "No downtime. The candidate master is deleted and the instance loses automatic failover."
+	mysqlHAPauseImpact    = "No downtime. While paused, a master failure does not trigger failover; resume after maintenance."
+	mysqlHAResumeImpact   = "No downtime. Automatic failover is active again once the candidate has caught up."
+	mysqlHAFailoverImpact = "Writes are unavailable for roughly 30-60 seconds while the candidate is promoted; existing connections are dropped and clients must reconnect to the same endpoint. The old master becomes the new candidate."
+)
+
+var (
+	mysqlHAChangeAction = mysqlLifecycleAction{
+		name:    "change high availability of",
+		allowed: []string{mysqlStatusAvailable},
+	}
+	mysqlFailoverAction = mysqlLifecycleAction{
+		name:    "fail over",
+		allowed: []string{mysqlStatusAvailable},
+	}
+)
+
+// mysqlHAInfo is GET /db-instances/{id}/high-availability, which the SDK lacks
+type mysqlHAInfo struct {
+	UseHighAvailability     bool `json:"useHighAvailability"`
+	PingInterval            int  `json:"pingInterval"`
+	FailoverReplWaitingTime int  `json:"failoverReplWaitingTime"`
+}
+
+type MySQLHAInstanceInput struct {
+	InstanceID string `json:"instance_id" jsonschema_description:"The ID of the master instance"`
+}
+
+type EnableMySQLHAInput struct {
+	InstanceID          string `json:"instance_id" jsonschema_description:"The ID of the master instance"`
+	PingIntervalSeconds int    `json:"ping_interval_seconds,omitempty" jsonschema_description:"Seconds between master health checks, 1-600 (default: service default)"`
+}
+
+type ConfirmMySQLHAInput struct {
+	InstanceID  string `json:"instance_id" jsonschema_description:"The ID of the master instance"`
+	ConfirmName string `json:"confirm_name" jsonschema_description:"The instance name, repeated to confirm"`
+}
+
+type ListMySQLFailoversInput struct {
+	InstanceID string `json:"instance_id,omitempty" jsonschema_description:"Only failovers of this instance (optional)"`
+	Days       int    `json:"days,omitempty" jsonschema_description:"How many days back to look (default 30, max 90)"`
+}
+
+// GetMySQLHAStatusOutput - output for reading HA status
+type GetMySQLHAStatusOutput struct {
+	InstanceID          string         `json:"instance_id"`
+	Name                string         `json:"name"`
+	Status              string         `json:"status"`
+	ProgressStatus      string         `json:"progress_status,omitempty"`
+	Enabled             bool           `json:"enabled"`
+	Paused              bool           `json:"paused"`
+	PingIntervalSeconds int            `json:"ping_interval_seconds,omitempty"`
+	Candidate           *MySQLInstance `json:"candidate,omitempty"`
+	FailoverReady       bool           `json:"failover_ready"`
+	Notes               []string       `json:"notes,omitempty"`
+}
+
+// MySQLHAActionOutput - output for HA operations
+type MySQLHAActionOutput struct {
+	InstanceID     string `json:"instance_id"`
+	Name           string `json:"name"`
+	Action         string `json:"action"`
+	PreviousStatus string `json:"previous_status"`
+	ExpectedImpact string `json:"expected_impact"`
+	JobID          string `json:"job_id"`
+}
+
+// MySQLFailoverEvent is one recorded failover
+type MySQLFailoverEvent struct {
+	Time         string `json:"time"`
+	InstanceID   string `json:"instance_id"`
+	InstanceName string `json:"instance_name"`
+	Code         string `json:"code"`
+	Message      string `json:"message,omitempty"`
+}
+
+// ListMySQLFailoversOutput - output for failover history
+type ListMySQLFailoversOutput struct {
+	From      string               `json:"from"`
+	To        string               `json:"to"`
+	Failovers []MySQLFailoverEvent `json:"failovers"`
+	Count     int                  `json:"count"`
+	// Truncated is set when the window held more events than could be read,
+	// so older failovers may be missing
+	TotalEvents int  `json:"total_events"`
+	Truncated   bool `json:"truncated"`
+}
+
+func registerMySQLHATools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_get_ha_status",
+		Description: "Show the high availability state of an NHN Cloud RDS MySQL master: whether HA is enabled or paused, the health check interval, the candidate master and whether a failover could run now.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[MySQLHAInstanceInput]) (*mcp.CallToolResultFor[GetMySQLHAStatusOutput], error) {
+		out, err := getMySQLHAStatus(ctx, cfg, params.Arguments.InstanceID)
+		if err != nil {
+			return &mcp.CallToolResultFor[GetMySQLHAStatusOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		text := fmt.Sprintf("High availability is disabled on %s", out.Name)
+		switch {
+		case out.Enabled && out.Paused:
+			text = fmt.Sprintf("High availability on %s is enabled but paused", out.Name)
+		case out.Enabled && out.Candidate != nil:
+			text = fmt.Sprintf("High availability on %s is enabled; candidate master %s is %s", out.Name, out.Candidate.Name, out.Candidate.Status)
+		case out.Enabled:
+			text = fmt.Sprintf("High availability on %s is enabled but no candidate master was found", out.Name)
+		}
+		for _, n := range out.Notes {
+			text += "\n" + n
+		}
+		return &mcp.CallToolResultFor[GetMySQLHAStatusOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_enable_ha",
+		Description: "Enable high availability on an NHN Cloud RDS MySQL master by creating a candidate master. " + mysqlHAEnableImpact,
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[EnableMySQLHAInput]) (*mcp.CallToolResultFor[MySQLHAActionOutput], error) {
+		out, err := enableMySQLHA(ctx, cfg, params.Arguments)
+		return mysqlHAActionResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_disable_ha",
+		Description: "Disable high availability on an NHN Cloud RDS MySQL master. " + mysqlHADisableImpact + " confirm_name must repeat the instance name.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ConfirmMySQLHAInput]) (*mcp.CallToolResultFor[MySQLHAActionOutput], error) {
+		out, err := disableMySQLHA(ctx, cfg, params.Arguments.InstanceID, params.Arguments.ConfirmName)
+		return mysqlHAActionResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_pause_ha",
+		Description: "Pause high availability on an NHN Cloud RDS MySQL master, e.g. before maintenance that would otherwise trigger a failover. " + mysqlHAPauseImpact,
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[MySQLHAInstanceInput]) (*mcp.CallToolResultFor[MySQLHAActionOutput], error) {
+		out, err := pauseMySQLHA(ctx, cfg, params.Arguments.InstanceID, true)
+		return mysqlHAActionResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_resume_ha",
+		Description: "Resume paused high availability on an NHN Cloud RDS MySQL master. " + mysqlHAResumeImpact,
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[MySQLHAInstanceInput]) (*mcp.CallToolResultFor[MySQLHAActionOutput], error) {
+		out, err := pauseMySQLHA(ctx, cfg, params.Arguments.InstanceID, false)
+		return mysqlHAActionResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_failover",
+		Description: "Trigger a manual failover of an NHN Cloud RDS MySQL master to its candidate master, by restarting it with online failover. " + mysqlHAFailoverImpact + " Requires HA enabled and not paused, and both instances AVAILABLE. confirm_name must repeat the instance name.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ConfirmMySQLHAInput]) (*mcp.CallToolResultFor[MySQLHAActionOutput], error) {
+		out, err := failoverMySQLInstance(ctx, cfg, params.Arguments.InstanceID, params.Arguments.ConfirmName)
+		return mysqlHAActionResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_list_failovers",
+		Description: "List recent NHN Cloud RDS MySQL failovers, automatic and manual, from the event history.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLFailoversInput]) (*mcp.CallToolResultFor[ListMySQLFailoversOutput], error) {
+		out, err := listMySQLFailovers(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[ListMySQLFailoversOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		text := fmt.Sprintf("Found %d failover events since %s", out.Count, out.From)
+		if out.Truncated {
+			text += "\n" + mysqlEventsTruncatedNote(out.TotalEvents)
+		}
+		return &mcp.CallToolResultFor[ListMySQLFailoversOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func mysqlHAActionResult(out MySQLHAActionOutput, err error) (*mcp.CallToolResultFor[MySQLHAActionOutput], error) {
+	if err != nil {
+		return &mcp.CallToolResultFor[MySQLHAActionOutput]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+			IsError: true,
+		}, nil
+	}
+	return &mcp.CallToolResultFor[MySQLHAActionOutput]{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Requested %s of instance %s (%s), previously %s (job %s)\nExpected impact: %s",
+			out.Action, out.Name, out.InstanceID, out.PreviousStatus, out.JobID, out.ExpectedImpact)}},
+		StructuredContent: out,
+	}, nil
+}
+
+func getMySQLHAStatus(ctx context.Context, cfg *config.Config, instanceID string) (GetMySQLHAStatusOutput, error) {
+	if instanceID == "" {
+		return GetMySQLHAStatusOutput{}, fmt.Errorf("instance_id is required")
+	}
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return GetMySQLHAStatusOutput{}, err
+	}
+
+	var state struct {
+		mysqlInstanceRecord
+		ProgressStatus string `json:"progressStatus"`
+	}
+	if err := api.get(ctx, "/db-instances/"+url.PathEscape(instanceID), nil, &state); err != nil {
+		return GetMySQLHAStatusOutput{}, fmt.Errorf("failed to get instance: %w", err)
+	}
+	if state.DBInstanceType != mysqlRoleMaster {
+		return GetMySQLHAStatusOutput{}, fmt.Errorf("instance %s is a %s; high availability is managed on the %s", state.DBInstanceName, state.DBInstanceType, mysqlRoleMaster)
+	}
+
+	var ha mysqlHAInfo
+	if err := api.get(ctx, "/db-instances/"+url.PathEscape(instanceID)+"/high-availability", nil, &ha); err != nil {
+		return GetMySQLHAStatusOutput{}, fmt.Errorf("failed to get high availability settings: %w", err)
+	}
+
+	out := GetMySQLHAStatusOutput{
+		InstanceID:     state.DBInstanceID,
+		Name:           state.DBInstanceName,
+		Status:         state.DBInstanceStatus,
+		ProgressStatus: state.ProgressStatus,
+		Enabled:        ha.UseHighAvailability,
+		// The API has no explicit pause flag; a paused instance reports it in progressStatus
+		Paused:              strings.Contains(strings.ToUpper(state.ProgressStatus), mysqlHAProgressPaused),
+		PingIntervalSeconds: ha.PingInterval,
+	}
+
+	if out.Enabled {
+		records, err := fetchMySQLInstanceRecords(ctx, api)
+		if err != nil {
+			return GetMySQLHAStatusOutput{}, err
+		}
+		for _, rec := range records {
+			if rec.DBInstanceGroupID == state.DBInstanceGroupID && rec.DBInstanceType == mysqlRoleCandidateMaster {
+				candidate := newMySQLInstance(rec)
+				out.Candidate = &candidate
+				break
+			}
+		}
+	}
+
+	switch {
+	case !out.Enabled:
+		out.Notes = append(out.Notes, "Failover is not possible; enable it with nhn_mysql_enable_ha")
+	case out.Paused:
+		out.Notes = append(out.Notes, "Automatic failover is suspended; resume it with nhn_mysql_resume_ha")
+	case out.Candidate == nil:
+		out.Notes = append(out.Notes, "No candidate master found; it may still be being built")
+	case out.Status != mysqlStatusAvailable || out.Candidate.Status != mysqlStatusAvailable:
+		out.Notes = append(out.Notes, fmt.Sprintf("Failover is not possible now: master is %s, candidate is %s", out.Status, out.Candidate.Status))
+	default:
+		out.FailoverReady = true
+	}
+	return out, nil
+}
+
+func enableMySQLHA(ctx context.Context, cfg *config.Config, in EnableMySQLHAInput) (MySQLHAActionOutput, error) {
+	if in.PingIntervalSeconds != 0 && (in.PingIntervalSeconds < mysqlHAMinPingInterval || in.PingIntervalSeconds > mysqlHAMaxPingInterval) {
+		return MySQLHAActionOutput{}, fmt.Errorf("ping_interval_seconds %d must be between %d and %d", in.PingIntervalSeconds, mysqlHAMinPingInterval, mysqlHAMaxPingInterval)
+	}
+	status, err := getMySQLHAStatus(ctx, cfg, in.InstanceID)
+	if err != nil {
+		return MySQLHAActionOutput{}, err
+	}
+	if status.Enabled {
+		return MySQLHAActionOutput{}, fmt.Errorf("high availability is already enabled on %s", status.Name)
+	}
+	if err := mysqlHAChangeAction.check(MySQLInstance{ID: status.InstanceID, Name: status.Name, Status: status.Status}); err != nil {
+		return MySQLHAActionOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLHAActionOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	result, err := client.MySQL().EnableHighAvailability(ctx, in.InstanceID, &mysql.EnableHAInput{
+		UseHighAvailability: true,
+		PingInterval:        in.PingIntervalSeconds,
+	})
+	if err != nil {
+		return MySQLHAActionOutput{}, fmt.Errorf("failed to enable high availability: %w", err)
+	}
+	return newMySQLHAActionOutput(status, "enable high availability", mysqlHAEnableImpact, result.JobID), nil
+}
+
+func disableMySQLHA(ctx context.Context, cfg *config.Config, instanceID, confirmName string) (MySQLHAActionOutput, error) {
+	status, err := getMySQLHAStatus(ctx, cfg, instanceID)
+	if err != nil {
+		return MySQLHAActionOutput{}, err
+	}
+	if err := checkMySQLConfirmName("disabling high availability", "instance "+instanceID, confirmName, status.Name); err != nil {
+		return MySQLHAActionOutput{}, err
+	}
+	if !status.Enabled {
+		return MySQLHAActionOutput{}, fmt.Errorf("high availability is not enabled on %s", status.Name)
+	}
+	if err := mysqlHAChangeAction.check(MySQLInstance{ID: status.InstanceID, Name: status.Name, Status: status.Status}); err != nil {
+		return MySQLHAActionOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLHAActionOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	result, err := client.MySQL().DisableHighAvailability(ctx, instanceID)
+	if err != nil {
+		return MySQLHAActionOutput{}, fmt.Errorf("failed to disable high availability: %w", err)
+	}
+	return newMySQLHAActionOutput(status, "disable high availability", mysqlHADisableImpact, result.JobID), nil
+}
+
+func pauseMySQLHA(ctx context.Context, cfg *config.Config, instanceID string, pause bool) (MySQLHAActionOutput, error) {
+	status, err := getMySQLHAStatus(ctx, cfg, instanceID)
+	if err != nil {
+		return MySQLHAActionOutput{}, err
+	}
+	if !status.Enabled {
+		return MySQLHAActionOutput{}, fmt.Errorf("high availability is not enabled on %s", status.Name)
+	}
+	if pause && status.Paused {
+		return MySQLHAActionOutput{}, fmt.Errorf("high availability on %s is already paused", status.Name)
+	}
+	if !pause && !status.Paused {
+		return MySQLHAActionOutput{}, fmt.Errorf("high availability on %s is not paused", status.Name)
+	}
+	if err := mysqlHAChangeAction.check(MySQLInstance{ID: status.InstanceID, Name: status.Name, Status: status.Status}); err != nil {
+		return MySQLHAActionOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLHAActionOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	rds := client.MySQL()
+
+	if pause {
+		result, err := rds.PauseHighAvailability(ctx, instanceID)
+		if err != nil {
+			return MySQLHAActionOutput{}, fmt.Errorf("failed to pause high availability: %w", err)
+		}
+		return newMySQLHAActionOutput(status, "pause high availability", mysqlHAPauseImpact, result.JobID), nil
+	}
+	result, err := rds.ResumeHighAvailability(ctx, instanceID)
+	if err != nil {
+		return MySQLHAActionOutput{}, fmt.Errorf("failed to resume high availability: %w", err)
+	}
+	return newMySQLHAActionOutput(status, "resume high availability", mysqlHAResumeImpact, result.JobID), nil
+}
+
+func failoverMySQLInstance(ctx context.Context, cfg *config.Config, instanceID, confirmName string) (MySQLHAActionOutput, error) {
+	status, err := getMySQLHAStatus(ctx, cfg, instanceID)
+	if err != nil {
+		return MySQLHAActionOutput{}, err
+	}
+	if err := checkMySQLConfirmName("failover", "instance "+instanceID, confirmName, status.Name); err != nil {
+		return MySQLHAActionOutput{}, err
+	}
+	if err := mysqlFailoverAction.check(MySQLInstance{ID: status.InstanceID, Name: status.Name, Status: status.Status}); err != nil {
+		return MySQLHAActionOutput{}, err
+	}
+	if !status.FailoverReady {
+		return MySQLHAActionOutput{}, fmt.Errorf("cannot fail over %s: %s", status.Name, strings.Join(status.Notes, "; "))
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLHAActionOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	result, err := client.MySQL().RestartInstance(ctx, instanceID, true)
+	if err != nil {
+		return MySQLHAActionOutput{}, fmt.Errorf("failed to fail over: %w", err)
+	}
+	return newMySQLHAActionOutput(status, fmt.Sprintf("failover to %s", status.Candidate.Name), mysqlHAFailoverImpact, result.JobID), nil
+}
+
+func listMySQLFailovers(ctx context.Context, cfg *config.Config, in ListMySQLFailoversInput) (ListMySQLFailoversOutput, error) {
+	days := in.Days
+	if days == 0 {
+		days = mysqlFailoverDefaultDays
+	}
+	if days < 1 || days > mysqlFailoverMaxDays {
+		return ListMySQLFailoversOutput{}, fmt.Errorf("days %d must be between 1 and %d", in.Days, mysqlFailoverMaxDays)
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return ListMySQLFailoversOutput{}, err
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -days)
+	events, total, err := fetchMySQLEvents(ctx, api, from, to, in.InstanceID)
+	if err != nil {
+		return ListMySQLFailoversOutput{}, err
+	}
+
+	out := ListMySQLFailoversOutput{
+		From:        from.Format(time.RFC3339),
+		To:          to.Format(time.RFC3339),
+		Failovers:   []MySQLFailoverEvent{},
+		TotalEvents: total,
+		Truncated:   len(events) < total,
+	}
+	for _, e := range events {
+		if e.kind() != "failover" {
+			continue
+		}
+		out.Failovers = append(out.Failovers, MySQLFailoverEvent{
+			Time:         e.EventYmdt,
+			InstanceID:   e.SourceID,
+			InstanceName: e.SourceName,
+			Code:         e.EventCode,
+			Message:      e.EventMessage,
+		})
+	}
+	out.Count = len(out.Failovers)
+	return out, nil
+}
+
+func newMySQLHAActionOutput(status GetMySQLHAStatusOutput, action, impact, jobID string) MySQLHAActionOutput {
+	return MySQLHAActionOutput{
+		InstanceID:     status.InstanceID,
+		Name:           status.Name,
+		Action:         action,
+		PreviousStatus: status.Status,
+		ExpectedImpact: impact,
+		JobID:          jobID,
+	}
+}
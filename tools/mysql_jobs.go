@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RDS for MySQL job statuses that end a job
+var (
+	mysqlJobSucceeded = []string{"SUCCEEDED", "COMPLETED"}
+	mysqlJobFailed    = []string{"FAILED", "CANCELED", "ERROR", "INTERRUPTED"}
+)
+
+// mysqlJobPollInterval is how often a running job is checked
+const mysqlJobPollInterval = 10 * time.Second
+
+// mysqlJob is the response of GET /jobs/{jobId}
+type mysqlJob struct {
+	JobID             string `json:"jobId"`
+	JobType           string `json:"jobType"`
+	JobStatus         string `json:"jobStatus"`
+	ResourceRelations []struct {
+		ResourceType string `json:"resourceType"`
+		ResourceID   string `json:"resourceId"`
+	} `json:"resourceRelations"`
+	CreatedYmdt string `json:"createdYmdt"`
+	UpdatedYmdt string `json:"updatedYmdt"`
+}
+
+func (j mysqlJob) succeeded() bool { return slices.Contains(mysqlJobSucceeded, j.JobStatus) }
+
+func (j mysqlJob) failed() bool { return slices.Contains(mysqlJobFailed, j.JobStatus) }
+
+type GetMySQLJobInput struct {
+	JobID string `json:"job_id" jsonschema_description:"The job ID returned by an asynchronous MySQL operation"`
+}
+
+// MySQLJob represents an asynchronous RDS operation
+type MySQLJob struct {
+	ID        string            `json:"id"`
+	Type      string            `json:"type"`
+	Status    string            `json:"status"`
+	Done      bool              `json:"done"`
+	Failed    bool              `json:"failed"`
+	Resources map[string]string `json:"resources,omitempty"`
+	CreatedAt string            `json:"created_at"`
+	UpdatedAt string            `json:"updated_at"`
+}
+
+func registerMySQLJobTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_get_job",
+		Description: "Get the status of an asynchronous NHN Cloud RDS MySQL operation by the job ID it returned.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[GetMySQLJobInput]) (*mcp.CallToolResultFor[MySQLJob], error) {
+		out, err := getMySQLJob(ctx, cfg, params.Arguments.JobID)
+		if err != nil {
+			return &mcp.CallToolResultFor[MySQLJob]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[MySQLJob]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Job %s (%s): %s", out.ID, out.Type, out.Status)}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func getMySQLJob(ctx context.Context, cfg *config.Config, jobID string) (MySQLJob, error) {
+	if jobID == "" {
+		return MySQLJob{}, fmt.Errorf("job_id is required")
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return MySQLJob{}, err
+	}
+
+	job, err := fetchMySQLJob(ctx, api, jobID)
+	if err != nil {
+		return MySQLJob{}, err
+	}
+	return newMySQLJob(job), nil
+}
+
+func newMySQLJob(job mysqlJob) MySQLJob {
+	out := MySQLJob{
+		ID:        job.JobID,
+		Type:      job.JobType,
+		Status:    job.JobStatus,
+		Done:      job.succeeded() || job.failed(),
+		Failed:    job.failed(),
+		CreatedAt: job.CreatedYmdt,
+		UpdatedAt: job.UpdatedYmdt,
+	}
+	if len(job.ResourceRelations) > 0 {
+		out.Resources = make(map[string]string, len(job.ResourceRelations))
+		for _, r := range job.ResourceRelations {
+			out.Resources[r.ResourceType] = r.ResourceID
+		}
+	}
+	return out
+}
+
+func fetchMySQLJob(ctx context.Context, api *mysqlAPI, jobID string) (mysqlJob, error) {
+	var job mysqlJob
+	if err := api.get(ctx, "/jobs/"+url.PathEscape(jobID), nil, &job); err != nil {
+		return mysqlJob{}, fmt.Errorf("failed to get job %s: %w", jobID, err)
+	}
+	return job, nil
+}
+
+// waitForMySQLJob polls a job until it ends or timeout passes. On timeout it
+// returns the last seen job without an error; callers check the status.
+// onChange, if set, is called with the job whenever its status changes.
+func waitForMySQLJob(ctx context.Context, api *mysqlAPI, jobID string, timeout time.Duration, onChange func(mysqlJob)) (mysqlJob, error) {
+	deadline := time.Now().Add(timeout)
+	var lastStatus string
+	for {
+		job, err := fetchMySQLJob(ctx, api, jobID)
+		if err != nil {
+			return mysqlJob{}, err
+		}
+		if onChange != nil && job.JobStatus != lastStatus {
+			onChange(job)
+			lastStatus = job.JobStatus
+		}
+		if job.succeeded() || job.failed() || time.Now().Add(mysqlJobPollInterval).After(deadline) {
+			return job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-time.After(mysqlJobPollInterval):
+		}
+	}
+}
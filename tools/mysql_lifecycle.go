@@ -0,0 +1,339 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/rds/mysql"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RDS for MySQL instance statuses (dbInstanceStatus)
+const (
+	mysqlStatusAvailable       = "AVAILABLE"
+	mysqlStatusBeforeCreate    = "BEFORE_CREATE"
+	mysqlStatusStorageFull     = "STORAGE_FULL"
+	mysqlStatusFailToCreate    = "FAIL_TO_CREATE"
+	mysqlStatusFailToConnect   = "FAIL_TO_CONNECT"
+	mysqlStatusReplicationStop = "REPLICATION_STOP"
+	mysqlStatusFailover        = "FAILOVER"
+	mysqlStatusShutdown        = "SHUTDOWN"
+	mysqlStatusDeleted         = "DELETED"
+)
+
+// mysqlLifecycleAction describes an instance operation and the statuses it may start from
+type mysqlLifecycleAction struct {
+	name    string
+	allowed []string
+}
+
+var (
+	mysqlStartAction = mysqlLifecycleAction{
+		name:    "start",
+		allowed: []string{mysqlStatusShutdown},
+	}
+	mysqlStopAction = mysqlLifecycleAction{
+		name:    "stop",
+		allowed: []string{mysqlStatusAvailable, mysqlStatusStorageFull, mysqlStatusReplicationStop},
+	}
+	mysqlRestartAction = mysqlLifecycleAction{
+		name:    "restart",
+		allowed: []string{mysqlStatusAvailable, mysqlStatusStorageFull, mysqlStatusReplicationStop, mysqlStatusFailToConnect},
+	}
+	mysqlDeleteAction = mysqlLifecycleAction{
+		name: "delete",
+		allowed: []string{
+			mysqlStatusAvailable, mysqlStatusStorageFull, mysqlStatusReplicationStop,
+			mysqlStatusFailToConnect, mysqlStatusFailToCreate, mysqlStatusShutdown,
+		},
+	}
+)
+
+// check rejects the action when the instance is in a status it cannot start from
+func (a mysqlLifecycleAction) check(inst MySQLInstance) error {
+	if slices.Contains(a.allowed, inst.Status) {
+		return nil
+	}
+
+	var hint string
+	switch inst.Status {
+	case mysqlStatusShutdown:
+		hint = "; start it first with nhn_mysql_start_instance"
+	case mysqlStatusAvailable:
+		if a.name == "start" {
+			hint = "; it is already running"
+		}
+	case mysqlStatusBeforeCreate, mysqlStatusFailover:
+		hint = "; wait until the current operation finishes"
+	case mysqlStatusDeleted:
+		hint = "; it has been deleted"
+	}
+	return fmt.Errorf("cannot %s instance %s (%s): status is %s, expected one of %s%s",
+		a.name, inst.Name, inst.ID, inst.Status, strings.Join(a.allowed, ", "), hint)
+}
+
+// checkMySQLConfirmName rejects a destructive action unless confirmName
+// repeats the name of the resource it applies to. The expected name is not
+// part of the error, so it cannot be confirmed by copying it from there.
+func checkMySQLConfirmName(action, resource, confirmName, name string) error {
+	if confirmName == name {
+		return nil
+	}
+	return fmt.Errorf("%s not confirmed: confirm_name must match the name of %s", action, resource)
+}
+
+type MySQLInstanceIDInput struct {
+	InstanceID string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+}
+
+type RestartMySQLInstanceInput struct {
+	InstanceID        string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+	UseOnlineFailover bool   `json:"use_online_failover,omitempty" jsonschema_description:"For high availability instances, fail over to the candidate master instead of restarting in place, reducing downtime"`
+}
+
+type DeleteMySQLInstanceInput struct {
+	InstanceID  string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+	ConfirmName string `json:"confirm_name" jsonschema_description:"The instance name, repeated to confirm deletion. Deletion is irreversible."`
+}
+
+type SetMySQLDeletionProtectionInput struct {
+	InstanceID  string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+	Enabled     bool   `json:"enabled" jsonschema_description:"true to protect the instance from deletion, false to allow deleting it"`
+	ConfirmName string `json:"confirm_name,omitempty" jsonschema_description:"The instance name, repeated to confirm turning protection off. Not needed to turn it on."`
+}
+
+// SetMySQLDeletionProtectionOutput - output for changing deletion protection
+type SetMySQLDeletionProtectionOutput struct {
+	InstanceID string `json:"instance_id"`
+	Name       string `json:"name"`
+	Enabled    bool   `json:"enabled"`
+	Changed    bool   `json:"changed"`
+	JobID      string `json:"job_id,omitempty"`
+}
+
+// MySQLInstanceActionOutput - output for instance lifecycle operations
+type MySQLInstanceActionOutput struct {
+	InstanceID     string `json:"instance_id"`
+	Name           string `json:"name"`
+	Action         string `json:"action"`
+	PreviousStatus string `json:"previous_status"`
+	JobID          string `json:"job_id"`
+}
+
+func registerMySQLLifecycleTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_start_instance",
+		Description: "Start a stopped (SHUTDOWN) NHN Cloud RDS MySQL instance.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[MySQLInstanceIDInput]) (*mcp.CallToolResultFor[MySQLInstanceActionOutput], error) {
+		out, err := startMySQLInstance(ctx, cfg, params.Arguments.InstanceID)
+		return mysqlInstanceActionResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_stop_instance",
+		Description: "Stop a running NHN Cloud RDS MySQL instance. A stopped instance keeps its storage and can be started again with nhn_mysql_start_instance.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[MySQLInstanceIDInput]) (*mcp.CallToolResultFor[MySQLInstanceActionOutput], error) {
+		out, err := stopMySQLInstance(ctx, cfg, params.Arguments.InstanceID)
+		return mysqlInstanceActionResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_restart_instance",
+		Description: "Restart a running NHN Cloud RDS MySQL instance. Set use_online_failover for high availability instances to fail over to the candidate master instead.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[RestartMySQLInstanceInput]) (*mcp.CallToolResultFor[MySQLInstanceActionOutput], error) {
+		out, err := restartMySQLInstance(ctx, cfg, params.Arguments.InstanceID, params.Arguments.UseOnlineFailover)
+		return mysqlInstanceActionResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_delete_instance",
+		Description: "Permanently delete an NHN Cloud RDS MySQL instance. confirm_name must repeat the instance name exactly. Instances with deletion protection enabled cannot be deleted.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[DeleteMySQLInstanceInput]) (*mcp.CallToolResultFor[MySQLInstanceActionOutput], error) {
+		out, err := deleteMySQLInstance(ctx, cfg, params.Arguments.InstanceID, params.Arguments.ConfirmName)
+		return mysqlInstanceActionResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_set_deletion_protection",
+		Description: "Turn deletion protection of an NHN Cloud RDS MySQL instance on or off. A protected instance cannot be deleted. Turning protection off requires confirm_name to repeat the instance name.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[SetMySQLDeletionProtectionInput]) (*mcp.CallToolResultFor[SetMySQLDeletionProtectionOutput], error) {
+		out, err := setMySQLDeletionProtection(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[SetMySQLDeletionProtectionOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		text := fmt.Sprintf("Deletion protection of instance %s (%s) is already %s", out.Name, out.InstanceID, onOff(out.Enabled))
+		if out.Changed {
+			text = fmt.Sprintf("Turned deletion protection of instance %s (%s) %s (job %s)", out.Name, out.InstanceID, onOff(out.Enabled), out.JobID)
+		}
+		return &mcp.CallToolResultFor[SetMySQLDeletionProtectionOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func mysqlInstanceActionResult(out MySQLInstanceActionOutput, err error) (*mcp.CallToolResultFor[MySQLInstanceActionOutput], error) {
+	if err != nil {
+		return &mcp.CallToolResultFor[MySQLInstanceActionOutput]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+			IsError: true,
+		}, nil
+	}
+	return &mcp.CallToolResultFor[MySQLInstanceActionOutput]{
+		Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Requested %s of instance %s (%s), previously %s (job %s)", out.Action, out.Name, out.InstanceID, out.PreviousStatus, out.JobID)}},
+		StructuredContent: out,
+	}, nil
+}
+
+func startMySQLInstance(ctx context.Context, cfg *config.Config, instanceID string) (MySQLInstanceActionOutput, error) {
+	inst, err := getMySQLInstance(ctx, cfg, instanceID)
+	if err != nil {
+		return MySQLInstanceActionOutput{}, err
+	}
+	if err := mysqlStartAction.check(inst.Instance); err != nil {
+		return MySQLInstanceActionOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLInstanceActionOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	result, err := client.MySQL().StartInstance(ctx, instanceID)
+	if err != nil {
+		return MySQLInstanceActionOutput{}, fmt.Errorf("failed to start instance: %w", err)
+	}
+	return newMySQLInstanceActionOutput(inst.Instance, "start", result.JobID), nil
+}
+
+func stopMySQLInstance(ctx context.Context, cfg *config.Config, instanceID string) (MySQLInstanceActionOutput, error) {
+	inst, err := getMySQLInstance(ctx, cfg, instanceID)
+	if err != nil {
+		return MySQLInstanceActionOutput{}, err
+	}
+	if err := mysqlStopAction.check(inst.Instance); err != nil {
+		return MySQLInstanceActionOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLInstanceActionOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	result, err := client.MySQL().StopInstance(ctx, instanceID)
+	if err != nil {
+		return MySQLInstanceActionOutput{}, fmt.Errorf("failed to stop instance: %w", err)
+	}
+	return newMySQLInstanceActionOutput(inst.Instance, "stop", result.JobID), nil
+}
+
+func restartMySQLInstance(ctx context.Context, cfg *config.Config, instanceID string, useOnlineFailover bool) (MySQLInstanceActionOutput, error) {
+	inst, err := getMySQLInstance(ctx, cfg, instanceID)
+	if err != nil {
+		return MySQLInstanceActionOutput{}, err
+	}
+	if err := mysqlRestartAction.check(inst.Instance); err != nil {
+		return MySQLInstanceActionOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLInstanceActionOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	result, err := client.MySQL().RestartInstance(ctx, instanceID, useOnlineFailover)
+	if err != nil {
+		if useOnlineFailover {
+			return MySQLInstanceActionOutput{}, fmt.Errorf("failed to restart instance with failover (is high availability enabled?): %w", err)
+		}
+		return MySQLInstanceActionOutput{}, fmt.Errorf("failed to restart instance: %w", err)
+	}
+
+	action := "restart"
+	if useOnlineFailover {
+		action = "restart with failover"
+	}
+	return newMySQLInstanceActionOutput(inst.Instance, action, result.JobID), nil
+}
+
+func deleteMySQLInstance(ctx context.Context, cfg *config.Config, instanceID, confirmName string) (MySQLInstanceActionOutput, error) {
+	inst, err := getMySQLInstance(ctx, cfg, instanceID)
+	if err != nil {
+		return MySQLInstanceActionOutput{}, err
+	}
+	if err := checkMySQLConfirmName("deletion", "instance "+instanceID, confirmName, inst.Instance.Name); err != nil {
+		return MySQLInstanceActionOutput{}, err
+	}
+	if err := mysqlDeleteAction.check(inst.Instance); err != nil {
+		return MySQLInstanceActionOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLInstanceActionOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	result, err := client.MySQL().DeleteInstance(ctx, instanceID)
+	if err != nil {
+		return MySQLInstanceActionOutput{}, fmt.Errorf("failed to delete instance: %w", err)
+	}
+	return newMySQLInstanceActionOutput(inst.Instance, "delete", result.JobID), nil
+}
+
+func newMySQLInstanceActionOutput(inst MySQLInstance, action, jobID string) MySQLInstanceActionOutput {
+	return MySQLInstanceActionOutput{
+		InstanceID:     inst.ID,
+		Name:           inst.Name,
+		Action:         action,
+		PreviousStatus: inst.Status,
+		JobID:          jobID,
+	}
+}
+
+func setMySQLDeletionProtection(ctx context.Context, cfg *config.Config, in SetMySQLDeletionProtectionInput) (SetMySQLDeletionProtectionOutput, error) {
+	if in.InstanceID == "" {
+		return SetMySQLDeletionProtectionOutput{}, fmt.Errorf("instance_id is required")
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return SetMySQLDeletionProtectionOutput{}, err
+	}
+	rec, err := fetchMySQLInstanceRecord(ctx, api, in.InstanceID)
+	if err != nil {
+		return SetMySQLDeletionProtectionOutput{}, err
+	}
+
+	out := SetMySQLDeletionProtectionOutput{
+		InstanceID: rec.DBInstanceID,
+		Name:       rec.DBInstanceName,
+		Enabled:    in.Enabled,
+	}
+	if rec.UseDeletionProtection == in.Enabled {
+		return out, nil
+	}
+	if !in.Enabled {
+		if err := checkMySQLConfirmName("turning deletion protection off", "instance "+in.InstanceID, in.ConfirmName, rec.DBInstanceName); err != nil {
+			return SetMySQLDeletionProtectionOutput{}, err
+		}
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return SetMySQLDeletionProtectionOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	result, err := client.MySQL().ModifyDeletionProtection(ctx, in.InstanceID, &mysql.ModifyDeletionProtectionInput{UseDeletionProtection: in.Enabled})
+	if err != nil {
+		return SetMySQLDeletionProtectionOutput{}, fmt.Errorf("failed to modify deletion protection: %w", err)
+	}
+	out.Changed = true
+	out.JobID = result.JobID
+	return out, nil
+}
@@ -0,0 +1,22 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckMySQLConfirmName(t *testing.T) {
+	if err := checkMySQLConfirmName("deletion", "instance i-1", "prod-db", "prod-db"); err != nil {
+		t.Errorf("matching name rejected: %v", err)
+	}
+	for _, confirm := range []string{"", "prod", "PROD-DB"} {
+		err := checkMySQLConfirmName("deletion", "instance i-1", confirm, "prod-db")
+		if err == nil {
+			t.Errorf("confirm_name %q accepted for prod-db", confirm)
+			continue
+		}
+		if strings.Contains(err.Error(), "prod-db") {
+			t.Errorf("error %q reveals the expected name", err)
+		}
+	}
+}
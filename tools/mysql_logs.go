@@ -0,0 +1,458 @@
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	mysqlLogDefaultTail    = 100
+	mysqlLogMaxTail        = 2000
+	mysqlLogDefaultMatches = 200
+	mysqlLogMaxMatches     = 2000
+	mysqlLogMaxLineBytes   = 1 << 20
+	mysqlLogExportTimeout  = 15 * time.Minute
+	mysqlLogExportMaxWait  = time.Hour
+	mysqlLogObjectPrefix   = "rds-logs"
+)
+
+// mysqlLogTypes classify log files by name
+var mysqlLogTypes = []struct {
+	logType string
+	match   string
+}{
+	{"error", "error"},
+	{"slow_query", "slow"},
+	{"general", "general"},
+	{"audit", "audit"},
+}
+
+func mysqlLogType(name string) string {
+	lower := strings.ToLower(name)
+	for _, t := range mysqlLogTypes {
+		if strings.Contains(lower, t.match) {
+			return t.logType
+		}
+	}
+	return "other"
+}
+
+type ListMySQLLogFilesInput struct {
+	InstanceID string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+}
+
+// MySQLLogFile is a log file on an instance
+type MySQLLogFile struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Bytes   int64  `json:"bytes"`
+	Updated string `json:"updated,omitempty"`
+}
+
+// ListMySQLLogFilesOutput - output for listing log files
+type ListMySQLLogFilesOutput struct {
+	InstanceID string         `json:"instance_id"`
+	LogFiles   []MySQLLogFile `json:"log_files"`
+	Count      int            `json:"count"`
+}
+
+type GetMySQLLogFileInput struct {
+	InstanceID     string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+	LogFileName    string `json:"log_file_name" jsonschema_description:"Log file name from nhn_mysql_list_log_files"`
+	Container      string `json:"container" jsonschema_description:"Object Storage container the log is exported through; it must already exist"`
+	ObjectPath     string `json:"object_path,omitempty" jsonschema_description:"Path in the container to export to (default rds-logs/<instance_id>/<timestamp>)"`
+	LocalPath      string `json:"local_path,omitempty" jsonschema_description:"Save the whole file to this local path (optional)"`
+	Overwrite      bool   `json:"overwrite,omitempty" jsonschema_description:"Replace local_path if it exists"`
+	TailLines      int    `json:"tail_lines,omitempty" jsonschema_description:"Return the last N lines (default 100 when neither grep nor local_path is set, max 2000)"`
+	Grep           string `json:"grep,omitempty" jsonschema_description:"Return only lines matching this regular expression"`
+	IgnoreCase     bool   `json:"ignore_case,omitempty" jsonschema_description:"Match grep case-insensitively"`
+	MaxMatches     int    `json:"max_matches,omitempty" jsonschema_description:"Maximum grep matches to return (default 200, max 2000)"`
+	TimeoutMinutes int    `json:"timeout_minutes,omitempty" jsonschema_description:"How long to wait for the export job (default 15, max 60)"`
+}
+
+// MySQLLogLine is one line of a log file
+type MySQLLogLine struct {
+	Number int    `json:"number"`
+	Text   string `json:"text"`
+}
+
+// GetMySQLLogFileOutput - output for reading a log file
+type GetMySQLLogFileOutput struct {
+	InstanceID    string         `json:"instance_id"`
+	LogFileName   string         `json:"log_file_name"`
+	Location      string         `json:"location"`
+	JobID         string         `json:"job_id"`
+	Bytes         int64          `json:"bytes"`
+	TotalLines    int            `json:"total_lines"`
+	SavedTo       string         `json:"saved_to,omitempty"`
+	Lines         []MySQLLogLine `json:"lines"`
+	Matches       int            `json:"matches,omitempty"`
+	MatchesCapped bool           `json:"matches_capped,omitempty"`
+}
+
+func registerMySQLLogTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_list_log_files",
+		Description: "List the log files of an NHN Cloud RDS MySQL instance (error, slow query, general, audit) with their sizes.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLLogFilesInput]) (*mcp.CallToolResultFor[ListMySQLLogFilesOutput], error) {
+		out, err := listMySQLLogFiles(ctx, cfg, params.Arguments.InstanceID)
+		if err != nil {
+			return &mcp.CallToolResultFor[ListMySQLLogFilesOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[ListMySQLLogFilesOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Found %d log files", out.Count)}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_get_log_file",
+		Description: "Fetch an NHN Cloud RDS MySQL log file by exporting it to an Object Storage container, then save it to a local path and/or return its last lines or the lines matching a regular expression. Needs the API user credentials (username, API password, tenant ID) in addition to the RDS credentials.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[GetMySQLLogFileInput]) (*mcp.CallToolResultFor[GetMySQLLogFileOutput], error) {
+		out, err := getMySQLLogFile(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[GetMySQLLogFileOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[GetMySQLLogFileOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: formatMySQLLogFile(out, params.Arguments.Grep)}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func formatMySQLLogFile(out GetMySQLLogFileOutput, grep string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d bytes, %d lines", out.LogFileName, out.Bytes, out.TotalLines)
+	if out.SavedTo != "" {
+		fmt.Fprintf(&b, ", saved to %s", out.SavedTo)
+	}
+	switch {
+	case grep != "":
+		fmt.Fprintf(&b, "\n%d lines match %q", out.Matches, grep)
+		if out.MatchesCapped {
+			fmt.Fprintf(&b, " (showing the first %d)", len(out.Lines))
+		}
+	case len(out.Lines) > 0:
+		fmt.Fprintf(&b, "\nLast %d lines:", len(out.Lines))
+	}
+	for _, l := range out.Lines {
+		fmt.Fprintf(&b, "\n%d: %s", l.Number, l.Text)
+	}
+	return b.String()
+}
+
+func listMySQLLogFiles(ctx context.Context, cfg *config.Config, instanceID string) (ListMySQLLogFilesOutput, error) {
+	if instanceID == "" {
+		return ListMySQLLogFilesOutput{}, fmt.Errorf("instance_id is required")
+	}
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return ListMySQLLogFilesOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	result, err := client.MySQL().ListLogFiles(ctx, instanceID)
+	if err != nil {
+		return ListMySQLLogFilesOutput{}, fmt.Errorf("failed to list log files: %w", err)
+	}
+
+	files := make([]MySQLLogFile, 0, len(result.LogFiles))
+	for _, f := range result.LogFiles {
+		files = append(files, MySQLLogFile{
+			Name:    f.LogFileName,
+			Type:    mysqlLogType(f.LogFileName),
+			Bytes:   f.LogFileSize,
+			Updated: f.UpdatedYmdt,
+		})
+	}
+	return ListMySQLLogFilesOutput{InstanceID: instanceID, LogFiles: files, Count: len(files)}, nil
+}
+
+func getMySQLLogFile(ctx context.Context, cfg *config.Config, in GetMySQLLogFileInput) (GetMySQLLogFileOutput, error) {
+	var problems validationErrors
+	if in.InstanceID == "" || in.LogFileName == "" {
+		problems.addf("instance_id and log_file_name are required")
+	}
+	if in.Container == "" {
+		problems.addf("container is required")
+	}
+	tail := in.TailLines
+	if tail == 0 && in.Grep == "" && in.LocalPath == "" {
+		tail = mysqlLogDefaultTail
+	}
+	if tail < 0 || tail > mysqlLogMaxTail {
+		problems.addf("tail_lines %d must be between 0 and %d", in.TailLines, mysqlLogMaxTail)
+	}
+	maxMatches := in.MaxMatches
+	if maxMatches == 0 {
+		maxMatches = mysqlLogDefaultMatches
+	}
+	if maxMatches < 1 || maxMatches > mysqlLogMaxMatches {
+		problems.addf("max_matches %d must be between 1 and %d", in.MaxMatches, mysqlLogMaxMatches)
+	}
+	var pattern *regexp.Regexp
+	if in.Grep != "" {
+		expr := in.Grep
+		if in.IgnoreCase {
+			expr = "(?i)" + expr
+		}
+		var err error
+		if pattern, err = regexp.Compile(expr); err != nil {
+			problems.addf("grep is not a valid regular expression: %v", err)
+		}
+	}
+	timeout := mysqlLogExportTimeout
+	if in.TimeoutMinutes != 0 {
+		timeout = time.Duration(in.TimeoutMinutes) * time.Minute
+	}
+	if timeout <= 0 || timeout > mysqlLogExportMaxWait {
+		problems.addf("timeout_minutes %d must be between 1 and %d", in.TimeoutMinutes, int(mysqlLogExportMaxWait.Minutes()))
+	}
+	if in.LocalPath != "" && !in.Overwrite {
+		if _, err := os.Stat(in.LocalPath); err == nil {
+			problems.addf("local_path %s already exists; set overwrite to replace it", in.LocalPath)
+		}
+	}
+	if err := problems.err(); err != nil {
+		return GetMySQLLogFileOutput{}, err
+	}
+
+	exported, err := exportMySQLLogFile(ctx, cfg, in.InstanceID, in.LogFileName, in.Container, in.ObjectPath, timeout)
+	if err != nil {
+		return GetMySQLLogFileOutput{}, err
+	}
+	defer exported.body.Close()
+
+	out := GetMySQLLogFileOutput{
+		InstanceID:  in.InstanceID,
+		LogFileName: in.LogFileName,
+		Location:    exported.location,
+		JobID:       exported.jobID,
+		Lines:       []MySQLLogLine{},
+	}
+
+	counter := &countingReader{r: exported.body}
+	if in.LocalPath == "" {
+		if err := scanMySQLLog(counter, tail, pattern, maxMatches, &out); err != nil {
+			return GetMySQLLogFileOutput{}, err
+		}
+		out.Bytes = counter.n
+		return out, nil
+	}
+
+	file, err := createMySQLLogFile(in.LocalPath, in.Overwrite)
+	if err != nil {
+		return GetMySQLLogFileOutput{}, err
+	}
+	// A plain download is copied as is, so lines of any length survive
+	if tail == 0 && pattern == nil {
+		_, err = io.Copy(file, counter)
+		out.TotalLines = counter.lines
+	} else {
+		err = scanMySQLLog(io.TeeReader(counter, file), tail, pattern, maxMatches, &out)
+	}
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write %s: %w", in.LocalPath, closeErr)
+	}
+	if err != nil {
+		os.Remove(in.LocalPath)
+		return GetMySQLLogFileOutput{}, err
+	}
+	out.Bytes = counter.n
+	out.SavedTo, _ = filepath.Abs(in.LocalPath)
+	return out, nil
+}
+
+// mysqlExportedLog is a log file exported to Object Storage, opened for reading
+type mysqlExportedLog struct {
+	body     io.ReadCloser
+	location string
+	jobID    string
+}
+
+// exportMySQLLogFile exports a log file to the container, waits for the job
+// and opens the exported object. The caller closes body.
+func exportMySQLLogFile(ctx context.Context, cfg *config.Config, instanceID, logFileName, container, objectPath string, timeout time.Duration) (mysqlExportedLog, error) {
+	_, tenantID, username, password := cfg.ObjectStorageCredentials()
+	if tenantID == "" || username == "" || password == "" {
+		return mysqlExportedLog{}, fmt.Errorf("exporting logs to Object Storage needs tenant ID, username and API password; set them with nhn_set_credential or in the credentials file")
+	}
+
+	files, err := listMySQLLogFiles(ctx, cfg, instanceID)
+	if err != nil {
+		return mysqlExportedLog{}, err
+	}
+	if !slices.ContainsFunc(files.LogFiles, func(f MySQLLogFile) bool { return f.Name == logFileName }) {
+		return mysqlExportedLog{}, fmt.Errorf("log file %s not found on instance %s; see nhn_mysql_list_log_files", logFileName, instanceID)
+	}
+
+	objectPath = strings.Trim(objectPath, "/")
+	if objectPath == "" {
+		objectPath = path.Join(mysqlLogObjectPrefix, instanceID, time.Now().UTC().Format("20060102T150405Z"))
+	}
+
+	// Fails early if the container is missing or the credentials are wrong
+	if _, err := listObjectStorageObjects(ctx, cfg, container, objectPath); err != nil {
+		return mysqlExportedLog{}, err
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return mysqlExportedLog{}, err
+	}
+	// The SDK has no log export call
+	var job mysqlAPIJob
+	err = api.post(ctx, "/db-instances/"+url.PathEscape(instanceID)+"/log-files/export", map[string]string{
+		"tenantId":        tenantID,
+		"username":        username,
+		"password":        password,
+		"targetContainer": container,
+		"objectPath":      objectPath,
+		"logFileName":     logFileName,
+	}, &job)
+	if err != nil {
+		return mysqlExportedLog{}, fmt.Errorf("failed to export log file: %w", err)
+	}
+
+	finished, err := waitForMySQLJob(ctx, api, job.JobID, timeout, nil)
+	if err != nil {
+		return mysqlExportedLog{}, fmt.Errorf("log export job %s started but could not be tracked: %w", job.JobID, err)
+	}
+	switch {
+	case finished.failed():
+		return mysqlExportedLog{}, fmt.Errorf("log export job %s ended with status %s", job.JobID, finished.JobStatus)
+	case !finished.succeeded():
+		return mysqlExportedLog{}, fmt.Errorf("log export job %s is still %s after %s; check it with nhn_mysql_get_job and run this again with the same object_path", job.JobID, finished.JobStatus, timeout)
+	}
+
+	// objectPath may be used as a folder or as the object name. Anything else
+	// under the prefix is a sibling path or an older export.
+	objects, err := listObjectStorageObjects(ctx, cfg, container, objectPath)
+	if err != nil {
+		return mysqlExportedLog{}, err
+	}
+	i := slices.IndexFunc(objects, func(o objectStorageObject) bool {
+		return o.Name == objectPath || (strings.HasPrefix(o.Name, objectPath+"/") && path.Base(o.Name) == logFileName)
+	})
+	if i < 0 {
+		return mysqlExportedLog{}, fmt.Errorf("export finished but %s was not found at %s/%s", logFileName, container, objectPath)
+	}
+	object := objects[i]
+
+	body, _, err := openObjectStorageObject(ctx, cfg, container, object.Name)
+	if err != nil {
+		return mysqlExportedLog{}, err
+	}
+	return mysqlExportedLog{
+		body:     body,
+		location: objectStorageURL(cfg, container, object.Name),
+		jobID:    job.JobID,
+	}, nil
+}
+
+// scanMySQLLog reads the whole log, keeping the last tail lines or, with a
+// pattern, the first maxMatches matching lines
+func scanMySQLLog(r io.Reader, tail int, pattern *regexp.Regexp, maxMatches int, out *GetMySQLLogFileOutput) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), mysqlLogMaxLineBytes)
+
+	var ring []MySQLLogLine
+	for scanner.Scan() {
+		out.TotalLines++
+		line := MySQLLogLine{Number: out.TotalLines, Text: scanner.Text()}
+
+		if pattern != nil {
+			if !pattern.MatchString(line.Text) {
+				continue
+			}
+			out.Matches++
+			if len(out.Lines) < maxMatches {
+				out.Lines = append(out.Lines, line)
+			} else {
+				out.MatchesCapped = true
+			}
+			continue
+		}
+		if tail > 0 {
+			if len(ring) == tail {
+				ring = ring[1:]
+			}
+			ring = append(ring, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return fmt.Errorf("line %d is longer than %d bytes", out.TotalLines+1, mysqlLogMaxLineBytes)
+		}
+		return fmt.Errorf("failed to read log: %w", err)
+	}
+	if pattern == nil {
+		out.Lines = append(out.Lines, ring...)
+	}
+	return nil
+}
+
+// createMySQLLogFile creates localPath readable only by the current user.
+// Without overwrite an existing file is never replaced.
+func createMySQLLogFile(localPath string, overwrite bool) (*os.File, error) {
+	if dir := filepath.Dir(localPath); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !overwrite {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(localPath, flags, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return nil, fmt.Errorf("local_path %s already exists; set overwrite to replace it", localPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", localPath, err)
+	}
+	return f, nil
+}
+
+// countingReader counts the bytes and lines read through it. A last line
+// without a trailing newline is counted too.
+type countingReader struct {
+	r     io.Reader
+	n     int64
+	lines int
+	open  bool
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	if n > 0 {
+		c.lines += bytes.Count(p[:n], []byte{'\n'})
+		c.open = p[n-1] != '\n'
+	}
+	if err == io.EOF && c.open {
+		c.lines++
+		c.open = false
+	}
+	return n, err
+}
@@ -0,0 +1,411 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	mysqlMetricsDefaultRange = time.Hour
+	mysqlMetricsMaxRange     = 31 * 24 * time.Hour
+	// mysqlMetricsTargetPoints bounds the series length when the interval is chosen automatically
+	mysqlMetricsTargetPoints = 360
+)
+
+// mysqlMetricIntervals are the aggregation intervals, in minutes, the API accepts
+var mysqlMetricIntervals = []int{1, 5, 30, 60, 360, 720, 1440}
+
+// mysqlMetricAliases map friendly metric names to keyword sets matched
+// against the measure names the API reports, most specific first
+var mysqlMetricAliases = []struct {
+	alias    string
+	keywords [][]string
+}{
+	{"cpu", [][]string{{"CPU", "USAGE"}, {"CPU"}}},
+	{"memory", [][]string{{"MEMORY", "USAGE"}, {"MEMORY", "USED"}, {"MEMORY"}}},
+	{"storage", [][]string{{"STORAGE", "USAGE"}, {"DISK", "USAGE"}, {"STORAGE"}}},
+	{"connections", [][]string{{"CONNECTION"}}},
+	{"qps", [][]string{{"QPS"}, {"QUERIES"}}},
+	{"replication_delay", [][]string{{"REPLICATION", "DELAY"}}},
+	{"slow_queries", [][]string{{"SLOW"}}},
+}
+
+// mysqlMeasure is one entry of GET /metrics
+type mysqlMeasure struct {
+	MeasureName string `json:"measureName"`
+	Unit        string `json:"unit"`
+}
+
+// mysqlMetricPoint is one value of a metric series
+type mysqlMetricPoint struct {
+	Time  string
+	Value float64
+}
+
+// mysqlMetricSeries is a decoded metric-statistics entry
+type mysqlMetricSeries struct {
+	MeasureName string
+	Unit        string
+	Points      []mysqlMetricPoint
+}
+
+type GetMySQLMetricsInput struct {
+	InstanceID      string   `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+	Metrics         []string `json:"metrics,omitempty" jsonschema_description:"cpu, memory, storage, connections, qps, replication_delay, slow_queries, or raw measure names (default: all of the friendly names)"`
+	From            string   `json:"from,omitempty" jsonschema_description:"Start of the range, RFC 3339 (default: one hour before to)"`
+	To              string   `json:"to,omitempty" jsonschema_description:"End of the range, RFC 3339 (default: now)"`
+	IntervalMinutes int      `json:"interval_minutes,omitempty" jsonschema_description:"Aggregation interval: 1, 5, 30, 60, 360, 720 or 1440 (default: chosen from the range)"`
+}
+
+// MySQLMetricSummary condenses a metric series
+type MySQLMetricSummary struct {
+	Count int     `json:"count"`
+	Min   float64 `json:"min"`
+	Avg   float64 `json:"avg"`
+	Max   float64 `json:"max"`
+	P95   float64 `json:"p95"`
+	Last  float64 `json:"last"`
+}
+
+// MySQLMetricPoint is one datapoint of a metric series
+type MySQLMetricPoint struct {
+	Time  string  `json:"time"`
+	Value float64 `json:"value"`
+}
+
+// MySQLMetric is a metric series with its summary
+type MySQLMetric struct {
+	Name        string             `json:"name"`
+	MeasureName string             `json:"measure_name"`
+	Unit        string             `json:"unit,omitempty"`
+	Summary     MySQLMetricSummary `json:"summary"`
+	Points      []MySQLMetricPoint `json:"points"`
+}
+
+// GetMySQLMetricsOutput - output for instance metrics
+type GetMySQLMetricsOutput struct {
+	InstanceID      string        `json:"instance_id"`
+	From            string        `json:"from"`
+	To              string        `json:"to"`
+	IntervalMinutes int           `json:"interval_minutes"`
+	Metrics         []MySQLMetric `json:"metrics"`
+	Unavailable     []string      `json:"unavailable,omitempty"`
+}
+
+func registerMySQLMetricTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_get_metrics",
+		Description: "Get NHN Cloud RDS MySQL monitoring statistics (CPU, memory, storage usage, connections, QPS, replication delay, slow queries) for an instance over a time range. The text result is a min/avg/max/p95 summary per metric; the raw series are in the structured result.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[GetMySQLMetricsInput]) (*mcp.CallToolResultFor[GetMySQLMetricsOutput], error) {
+		out, err := getMySQLMetrics(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[GetMySQLMetricsOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[GetMySQLMetricsOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: formatMySQLMetricsSummary(out)}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func formatMySQLMetricsSummary(out GetMySQLMetricsOutput) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Metrics for %s from %s to %s (%d-minute interval)", out.InstanceID, out.From, out.To, out.IntervalMinutes)
+	for _, m := range out.Metrics {
+		unit := ""
+		if m.Unit != "" {
+			unit = " " + m.Unit
+		}
+		if m.Summary.Count == 0 {
+			fmt.Fprintf(&b, "\n%s (%s): no data", m.Name, m.MeasureName)
+			continue
+		}
+		s := m.Summary
+		fmt.Fprintf(&b, "\n%s (%s%s): min %.2f, avg %.2f, max %.2f, p95 %.2f, last %.2f over %d points",
+			m.Name, m.MeasureName, unit, s.Min, s.Avg, s.Max, s.P95, s.Last, s.Count)
+	}
+	if len(out.Unavailable) > 0 {
+		fmt.Fprintf(&b, "\nNot available: %s", strings.Join(out.Unavailable, ", "))
+	}
+	return b.String()
+}
+
+func getMySQLMetrics(ctx context.Context, cfg *config.Config, in GetMySQLMetricsInput) (GetMySQLMetricsOutput, error) {
+	var problems validationErrors
+	if in.InstanceID == "" {
+		problems.addf("instance_id is required")
+	}
+	to := time.Now()
+	if in.To != "" {
+		t, err := time.Parse(time.RFC3339, in.To)
+		if err != nil {
+			problems.addf("to %q is not an RFC 3339 time", in.To)
+		} else {
+			to = t
+		}
+	}
+	from := to.Add(-mysqlMetricsDefaultRange)
+	if in.From != "" {
+		t, err := time.Parse(time.RFC3339, in.From)
+		if err != nil {
+			problems.addf("from %q is not an RFC 3339 time", in.From)
+		} else {
+			from = t
+		}
+	}
+	if !from.Before(to) {
+		problems.addf("from must be before to")
+	} else if to.Sub(from) > mysqlMetricsMaxRange {
+		problems.addf("the range may be at most %d days", int(mysqlMetricsMaxRange.Hours()/24))
+	}
+	interval := in.IntervalMinutes
+	if interval == 0 {
+		interval = chooseMySQLMetricInterval(to.Sub(from))
+	} else if !slices.Contains(mysqlMetricIntervals, interval) {
+		problems.addf("interval_minutes %d must be one of %v", interval, mysqlMetricIntervals)
+	}
+	if err := problems.err(); err != nil {
+		return GetMySQLMetricsOutput{}, err
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return GetMySQLMetricsOutput{}, err
+	}
+	measures, err := listMySQLMeasures(ctx, api)
+	if err != nil {
+		return GetMySQLMetricsOutput{}, err
+	}
+
+	names := in.Metrics
+	if len(names) == 0 {
+		for _, a := range mysqlMetricAliases {
+			names = append(names, a.alias)
+		}
+	}
+
+	out := GetMySQLMetricsOutput{
+		InstanceID:      in.InstanceID,
+		From:            from.Format(time.RFC3339),
+		To:              to.Format(time.RFC3339),
+		IntervalMinutes: interval,
+		Metrics:         []MySQLMetric{},
+	}
+	// requested keeps the request order; aliasOf maps each measure back to the name asked for
+	var requested []string
+	aliasOf := make(map[string]string)
+	for _, name := range names {
+		measure := resolveMySQLMeasure(measures, name)
+		if measure == "" {
+			out.Unavailable = append(out.Unavailable, name)
+			continue
+		}
+		if _, dup := aliasOf[measure]; dup {
+			continue
+		}
+		aliasOf[measure] = name
+		requested = append(requested, measure)
+	}
+	if len(requested) == 0 {
+		available := make([]string, 0, len(measures))
+		for _, m := range measures {
+			available = append(available, m.MeasureName)
+		}
+		return GetMySQLMetricsOutput{}, fmt.Errorf("none of the requested metrics is available; available measures: %s", strings.Join(available, ", "))
+	}
+
+	series, err := fetchMySQLMetricSeries(ctx, api, in.InstanceID, requested, from, to, interval)
+	if err != nil {
+		return GetMySQLMetricsOutput{}, err
+	}
+	byMeasure := make(map[string]mysqlMetricSeries, len(series))
+	for _, s := range series {
+		byMeasure[s.MeasureName] = s
+	}
+
+	for _, measure := range requested {
+		s := byMeasure[measure]
+		m := MySQLMetric{
+			Name:        aliasOf[measure],
+			MeasureName: measure,
+			Unit:        s.Unit,
+			Points:      make([]MySQLMetricPoint, 0, len(s.Points)),
+		}
+		values := make([]float64, 0, len(s.Points))
+		for _, p := range s.Points {
+			m.Points = append(m.Points, MySQLMetricPoint{Time: p.Time, Value: p.Value})
+			values = append(values, p.Value)
+		}
+		m.Summary = summarizeMySQLMetric(values)
+		out.Metrics = append(out.Metrics, m)
+	}
+	return out, nil
+}
+
+// chooseMySQLMetricInterval picks the finest interval that keeps the series
+// within mysqlMetricsTargetPoints
+func chooseMySQLMetricInterval(span time.Duration) int {
+	for _, i := range mysqlMetricIntervals {
+		if span/(time.Duration(i)*time.Minute) <= mysqlMetricsTargetPoints {
+			return i
+		}
+	}
+	return mysqlMetricIntervals[len(mysqlMetricIntervals)-1]
+}
+
+// summarizeMySQLMetric computes min/avg/max, the nearest-rank 95th percentile
+// and the last value of values, which are in time order
+func summarizeMySQLMetric(values []float64) MySQLMetricSummary {
+	if len(values) == 0 {
+		return MySQLMetricSummary{}
+	}
+	s := MySQLMetricSummary{Count: len(values), Min: values[0], Max: values[0], Last: values[len(values)-1]}
+	sum := 0.0
+	for _, v := range values {
+		s.Min = math.Min(s.Min, v)
+		s.Max = math.Max(s.Max, v)
+		sum += v
+	}
+	s.Avg = sum / float64(len(values))
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	rank := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+	s.P95 = sorted[max(rank, 0)]
+	return s
+}
+
+// resolveMySQLMeasure maps a friendly metric name or a raw measure name to an
+// available measure name, or "" if there is none
+func resolveMySQLMeasure(measures []mysqlMeasure, name string) string {
+	for _, m := range measures {
+		if strings.EqualFold(m.MeasureName, name) {
+			return m.MeasureName
+		}
+	}
+	for _, a := range mysqlMetricAliases {
+		if strings.EqualFold(a.alias, name) {
+			return matchMySQLMeasure(measures, a.keywords...)
+		}
+	}
+	return ""
+}
+
+// matchMySQLMeasure returns the first measure name containing every keyword of
+// the first keyword set that matches anything. Measure names differ between
+// API versions, so they are looked up rather than hard-coded.
+func matchMySQLMeasure(measures []mysqlMeasure, keywordSets ...[]string) string {
+	for _, keywords := range keywordSets {
+		for _, m := range measures {
+			name := strings.ToUpper(m.MeasureName)
+			if !slices.ContainsFunc(keywords, func(k string) bool { return !strings.Contains(name, k) }) {
+				return m.MeasureName
+			}
+		}
+	}
+	return ""
+}
+
+func listMySQLMeasures(ctx context.Context, api *mysqlAPI) ([]mysqlMeasure, error) {
+	var result struct {
+		Metrics []mysqlMeasure `json:"metrics"`
+	}
+	if err := api.get(ctx, "/metrics", nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to list metrics: %w", err)
+	}
+	return result.Metrics, nil
+}
+
+// findMySQLMeasure returns the first available measure name containing every keyword
+func findMySQLMeasure(ctx context.Context, api *mysqlAPI, keywords ...string) (string, error) {
+	measures, err := listMySQLMeasures(ctx, api)
+	if err != nil {
+		return "", err
+	}
+	if name := matchMySQLMeasure(measures, keywords); name != "" {
+		return name, nil
+	}
+	return "", fmt.Errorf("no metric matching %s is available", strings.Join(keywords, "+"))
+}
+
+// fetchMySQLMetricSeries reads metric statistics. The SDK call cannot be used
+// because it escapes the query string into the path.
+func fetchMySQLMetricSeries(ctx context.Context, api *mysqlAPI, instanceID string, measures []string, from, to time.Time, intervalMinutes int) ([]mysqlMetricSeries, error) {
+	query := url.Values{
+		"dbInstanceId": {instanceID},
+		"measureNames": {strings.Join(measures, ",")},
+		"from":         {from.Format(time.RFC3339)},
+		"to":           {to.Format(time.RFC3339)},
+		"interval":     {strconv.Itoa(intervalMinutes)},
+	}
+	var result struct {
+		MetricStatistics []struct {
+			MeasureName string              `json:"measureName"`
+			Unit        string              `json:"unit"`
+			Values      [][]json.RawMessage `json:"values"`
+		} `json:"metricStatistics"`
+	}
+	if err := api.get(ctx, "/metric-statistics", query, &result); err != nil {
+		return nil, fmt.Errorf("failed to get metric statistics: %w", err)
+	}
+
+	series := make([]mysqlMetricSeries, 0, len(result.MetricStatistics))
+	for _, m := range result.MetricStatistics {
+		s := mysqlMetricSeries{MeasureName: m.MeasureName, Unit: m.Unit}
+		for _, v := range m.Values {
+			if len(v) < 2 {
+				continue
+			}
+			value, ok := parseMySQLMetricNumber(v[1])
+			if !ok {
+				// Gaps are reported as null
+				continue
+			}
+			s.Points = append(s.Points, mysqlMetricPoint{Time: parseMySQLMetricTime(v[0]), Value: value})
+		}
+		series = append(series, s)
+	}
+	return series, nil
+}
+
+// parseMySQLMetricNumber accepts numbers and numeric strings
+func parseMySQLMetricNumber(raw json.RawMessage) (float64, bool) {
+	var f float64
+	if err := json.Unmarshal(raw, &f); err == nil {
+		return f, true
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// parseMySQLMetricTime normalizes epoch seconds or milliseconds to RFC 3339
+// and passes strings through
+func parseMySQLMetricTime(raw json.RawMessage) string {
+	if ts, ok := parseMySQLMetricNumber(raw); ok {
+		if ts > 1e12 {
+			return time.UnixMilli(int64(ts)).UTC().Format(time.RFC3339)
+		}
+		return time.Unix(int64(ts), 0).UTC().Format(time.RFC3339)
+	}
+	var s string
+	_ = json.Unmarshal(raw, &s)
+	return s
+}
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeMySQLMetric(t *testing.T) {
+	seq := func(n int) []float64 {
+		values := make([]float64, n)
+		for i := range values {
+			values[i] = float64(n - i) // descending, so sorting matters
+		}
+		return values
+	}
+
+	tests := []struct {
+		name   string
+		values []float64
+		want   MySQLMetricSummary
+	}{
+		{"empty", nil, MySQLMetricSummary{}},
+		{"single point", []float64{42}, MySQLMetricSummary{Count: 1, Min: 42, Avg: 42, Max: 42, P95: 42, Last: 42}},
+		{"nearest rank of 20", seq(20), MySQLMetricSummary{Count: 20, Min: 1, Avg: 10.5, Max: 20, P95: 19, Last: 1}},
+		{"nearest rank of 100", seq(100), MySQLMetricSummary{Count: 100, Min: 1, Avg: 50.5, Max: 100, P95: 95, Last: 1}},
+		// ceil(0.95*3) = 3, so the top value is the 95th percentile
+		{"small series", []float64{3, 1, 2}, MySQLMetricSummary{Count: 3, Min: 1, Avg: 2, Max: 3, P95: 3, Last: 2}},
+	}
+	for _, tt := range tests {
+		if got := summarizeMySQLMetric(tt.values); got != tt.want {
+			t.Errorf("%s: summarizeMySQLMetric = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestChooseMySQLMetricInterval(t *testing.T) {
+	tests := []struct {
+		span time.Duration
+		want int
+	}{
+		{time.Hour, 1},
+		{6 * time.Hour, 1},
+		{6*time.Hour + time.Minute, 5},
+		{24 * time.Hour, 5},
+		{7 * 24 * time.Hour, 30},
+		{31 * 24 * time.Hour, 360},
+		{2 * 365 * 24 * time.Hour, 1440},
+	}
+	for _, tt := range tests {
+		if got := chooseMySQLMetricInterval(tt.span); got != tt.want {
+			t.Errorf("chooseMySQLMetricInterval(%s) = %d, want %d", tt.span, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/rds/mysql"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type ModifyMySQLInstanceInput struct {
+	InstanceID        string   `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+	Name              string   `json:"name,omitempty" jsonschema_description:"New instance name (optional)"`
+	Description       string   `json:"description,omitempty" jsonschema_description:"New description (optional)"`
+	Flavor            string   `json:"flavor,omitempty" jsonschema_description:"New flavor ID or name (optional, restarts the instance)"`
+	StorageSizeGB     int      `json:"storage_size_gb,omitempty" jsonschema_description:"New storage size in GB; may only grow (optional, restarts the instance, must be requested on its own)"`
+	Port              int      `json:"port,omitempty" jsonschema_description:"New DB port, 3306-43306 (optional, restarts the instance)"`
+	ParameterGroupID  string   `json:"parameter_group_id,omitempty" jsonschema_description:"New parameter group ID for the instance's DB version (optional, may restart the instance)"`
+	SecurityGroupIDs  []string `json:"security_group_ids,omitempty" jsonschema_description:"Replacement list of DB security group IDs (optional)"`
+	UseOnlineFailover bool     `json:"use_online_failover,omitempty" jsonschema_description:"For high availability instances, apply restarting changes by failing over to reduce downtime"`
+	ExecuteBackup     bool     `json:"execute_backup,omitempty" jsonschema_description:"Take a backup before applying restarting changes"`
+	DryRun            bool     `json:"dry_run,omitempty" jsonschema_description:"Only validate and report the changes without applying them"`
+}
+
+// MySQLInstanceChange is a single field change of a modification
+type MySQLInstanceChange struct {
+	Field           string `json:"field"`
+	Before          string `json:"before"`
+	After           string `json:"after"`
+	RequiresRestart bool   `json:"requires_restart"`
+}
+
+// ModifyMySQLInstanceOutput - output for modifying a MySQL instance
+type ModifyMySQLInstanceOutput struct {
+	InstanceID      string                `json:"instance_id"`
+	Name            string                `json:"name"`
+	Changes         []MySQLInstanceChange `json:"changes"`
+	RestartRequired bool                  `json:"restart_required"`
+	Warnings        []string              `json:"warnings,omitempty"`
+	DryRun          bool                  `json:"dry_run"`
+	JobID           string                `json:"job_id,omitempty"`
+}
+
+func registerMySQLModifyTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_modify_instance",
+		Description: "Modify an NHN Cloud RDS MySQL instance: rename, change description, resize flavor, expand storage, change port, or reassign parameter and security groups. Only fields that differ from the current instance are changed. Reports before/after values and warns when the change restarts the instance. Storage can only grow. Use dry_run to preview.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ModifyMySQLInstanceInput]) (*mcp.CallToolResultFor[ModifyMySQLInstanceOutput], error) {
+		out, err := modifyMySQLInstance(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[ModifyMySQLInstanceOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[ModifyMySQLInstanceOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: formatMySQLModifySummary(out)}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func formatMySQLModifySummary(out ModifyMySQLInstanceOutput) string {
+	var b strings.Builder
+	switch {
+	case len(out.Changes) == 0:
+		fmt.Fprintf(&b, "Instance %s (%s) already matches the requested values; nothing to change", out.Name, out.InstanceID)
+		return b.String()
+	case out.DryRun:
+		fmt.Fprintf(&b, "Dry run for instance %s (%s):", out.Name, out.InstanceID)
+	default:
+		fmt.Fprintf(&b, "Modifying instance %s (%s):", out.Name, out.InstanceID)
+	}
+	for _, c := range out.Changes {
+		fmt.Fprintf(&b, "\n- %s: %s -> %s", c.Field, c.Before, c.After)
+		if c.RequiresRestart {
+			b.WriteString(" (restart)")
+		}
+	}
+	for _, w := range out.Warnings {
+		fmt.Fprintf(&b, "\nWarning: %s", w)
+	}
+	if out.JobID != "" {
+		fmt.Fprintf(&b, "\nJob: %s", out.JobID)
+	}
+	return b.String()
+}
+
+func modifyMySQLInstance(ctx context.Context, cfg *config.Config, in ModifyMySQLInstanceInput) (ModifyMySQLInstanceOutput, error) {
+	if in.InstanceID == "" {
+		return ModifyMySQLInstanceOutput{}, fmt.Errorf("instance_id is required")
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return ModifyMySQLInstanceOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	rds := client.MySQL()
+
+	current, err := rds.GetInstance(ctx, in.InstanceID)
+	if err != nil {
+		return ModifyMySQLInstanceOutput{}, fmt.Errorf("failed to get instance: %w", err)
+	}
+	if current.DBInstanceStatus != mysqlStatusAvailable {
+		return ModifyMySQLInstanceOutput{}, fmt.Errorf("cannot modify instance %s (%s): status is %s, expected %s",
+			current.DBInstanceName, current.DBInstanceID, current.DBInstanceStatus, mysqlStatusAvailable)
+	}
+
+	out := ModifyMySQLInstanceOutput{
+		InstanceID: current.DBInstanceID,
+		Name:       current.DBInstanceName,
+		DryRun:     in.DryRun,
+	}
+	req := &mysql.ModifyInstanceInput{
+		UseOnlineFailover: in.UseOnlineFailover,
+		ExecuteBackup:     in.ExecuteBackup,
+	}
+	var problems validationErrors
+	change := func(field, before, after string, restart bool) {
+		out.Changes = append(out.Changes, MySQLInstanceChange{Field: field, Before: before, After: after, RequiresRestart: restart})
+		out.RestartRequired = out.RestartRequired || restart
+	}
+
+	if in.Name != "" && in.Name != current.DBInstanceName {
+		if !mysqlInstanceNamePattern.MatchString(in.Name) {
+			problems.addf("name %q must be 1-100 characters of letters, digits, '.', '_' or '-', starting with a letter", in.Name)
+		} else {
+			req.DBInstanceName = in.Name
+			change("name", current.DBInstanceName, in.Name, false)
+		}
+	}
+
+	if in.Description != "" && in.Description != current.Description {
+		req.Description = in.Description
+		change("description", current.Description, in.Description, false)
+	}
+
+	if in.Flavor != "" {
+		flavors, err := rds.ListFlavors(ctx)
+		if err != nil {
+			return ModifyMySQLInstanceOutput{}, fmt.Errorf("failed to list flavors: %w", err)
+		}
+		currentName := current.DBFlavorID
+		for _, f := range flavors.DBFlavors {
+			if f.FlavorID == current.DBFlavorID {
+				currentName = f.FlavorName
+			}
+		}
+		target, err := resolveMySQLFlavor(ctx, rds, in.Flavor)
+		if err != nil {
+			problems.addf("%v", err)
+		} else if target.FlavorID != current.DBFlavorID {
+			req.DBFlavorID = target.FlavorID
+			change("flavor", currentName, target.FlavorName, true)
+		}
+	}
+
+	if in.Port != 0 && in.Port != current.DBPort {
+		if in.Port < mysqlMinPort || in.Port > mysqlMaxPort {
+			problems.addf("port %d must be between %d and %d", in.Port, mysqlMinPort, mysqlMaxPort)
+		} else {
+			req.DBPort = in.Port
+			change("port", strconv.Itoa(current.DBPort), strconv.Itoa(in.Port), true)
+		}
+	}
+
+	if in.ParameterGroupID != "" && in.ParameterGroupID != current.ParameterGroupID {
+		groups, err := rds.ListParameterGroups(ctx, "")
+		if err != nil {
+			return ModifyMySQLInstanceOutput{}, fmt.Errorf("failed to list parameter groups: %w", err)
+		}
+		names := make(map[string]string, len(groups.ParameterGroups))
+		found := false
+		for _, g := range groups.ParameterGroups {
+			names[g.ParameterGroupID] = g.ParameterGroupName
+			if g.ParameterGroupID != in.ParameterGroupID {
+				continue
+			}
+			found = true
+			if g.DBVersion != current.DBVersion {
+				problems.addf("parameter group %s is for %s, but the instance runs %s", g.ParameterGroupName, g.DBVersion, current.DBVersion)
+			}
+		}
+		if !found {
+			problems.addf("parameter_group_id %q not found", in.ParameterGroupID)
+		} else {
+			req.ParameterGroupID = in.ParameterGroupID
+			change("parameter_group", labelOr(names[current.ParameterGroupID], current.ParameterGroupID), names[in.ParameterGroupID], true)
+		}
+	}
+
+	if in.SecurityGroupIDs != nil && len(in.SecurityGroupIDs) == 0 {
+		// ModifyInstanceInput omits an empty list, so it would be silently ignored
+		problems.addf("security_group_ids must list at least one DB security group; omit it to keep the current groups")
+	} else if in.SecurityGroupIDs != nil && !sameStringSet(in.SecurityGroupIDs, current.DBSecurityGroupIDs) {
+		sgs, err := rds.ListSecurityGroups(ctx)
+		if err != nil {
+			return ModifyMySQLInstanceOutput{}, fmt.Errorf("failed to list DB security groups: %w", err)
+		}
+		known := make(map[string]bool, len(sgs.DBSecurityGroups))
+		for _, g := range sgs.DBSecurityGroups {
+			known[g.DBSecurityGroupID] = true
+		}
+		for _, id := range in.SecurityGroupIDs {
+			if !known[id] {
+				problems.addf("security group %q not found", id)
+			}
+		}
+		req.DBSecurityGroupIDs = in.SecurityGroupIDs
+		change("security_groups", strings.Join(current.DBSecurityGroupIDs, ", "), strings.Join(in.SecurityGroupIDs, ", "), false)
+	}
+
+	storageChange := in.StorageSizeGB != 0 && in.StorageSizeGB != current.StorageSize
+	if storageChange {
+		switch {
+		case in.StorageSizeGB < current.StorageSize:
+			problems.addf("storage_size_gb %d is smaller than the current %d GB; storage can only grow", in.StorageSizeGB, current.StorageSize)
+		case in.StorageSizeGB > mysqlMaxStorageGB:
+			problems.addf("storage_size_gb %d exceeds the maximum of %d", in.StorageSizeGB, mysqlMaxStorageGB)
+		case len(out.Changes) > 0:
+			problems.addf("storage expansion must be requested on its own; the instance is busy while other changes are applied")
+		default:
+			change("storage_size_gb", strconv.Itoa(current.StorageSize), strconv.Itoa(in.StorageSizeGB), true)
+		}
+	}
+
+	if err := problems.err(); err != nil {
+		return ModifyMySQLInstanceOutput{}, err
+	}
+
+	for _, c := range out.Changes {
+		if c.Field == "parameter_group" {
+			out.Warnings = append(out.Warnings, "changing the parameter group restarts the instance if the new group differs in parameters that require a restart")
+		}
+	}
+	if out.RestartRequired {
+		if in.UseOnlineFailover {
+			out.Warnings = append(out.Warnings, "the instance restarts via failover to its candidate master; connections are briefly interrupted")
+		} else {
+			out.Warnings = append(out.Warnings, "the instance restarts and is unavailable until the change completes")
+		}
+	}
+
+	if in.DryRun || len(out.Changes) == 0 {
+		return out, nil
+	}
+
+	if storageChange {
+		result, err := rds.ModifyStorageInfo(ctx, in.InstanceID, &mysql.ModifyStorageInfoInput{
+			StorageSize:       in.StorageSizeGB,
+			UseOnlineFailover: in.UseOnlineFailover,
+		})
+		if err != nil {
+			return ModifyMySQLInstanceOutput{}, fmt.Errorf("failed to modify storage: %w", err)
+		}
+		out.JobID = result.JobID
+		return out, nil
+	}
+
+	// The SDK's ModifyInstance drops the job ID from the response
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return ModifyMySQLInstanceOutput{}, err
+	}
+	var job mysqlAPIJob
+	if err := api.put(ctx, "/db-instances/"+url.PathEscape(in.InstanceID), req, &job); err != nil {
+		return ModifyMySQLInstanceOutput{}, fmt.Errorf("failed to modify instance: %w", err)
+	}
+	out.JobID = job.JobID
+	return out, nil
+}
+
+// sameStringSet reports whether a and b contain the same values in any order
+func sameStringSet(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// labelOr returns label, or fallback when label is empty
+func labelOr(label, fallback string) string {
+	if label == "" {
+		return fallback
+	}
+	return label
+}
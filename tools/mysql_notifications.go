@@ -0,0 +1,764 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	mysqlWatchDefaultDuration = 5
+	mysqlWatchMaxDuration     = 1440
+)
+
+// mysqlWatchComparisons maps the comparison operators accepted by the watch
+// rule tools to the API's codes
+var mysqlWatchComparisons = map[string]string{
+	">":   "GT",
+	">=":  "GTE",
+	"<":   "LT",
+	"<=":  "LTE",
+	"GT":  "GT",
+	"GTE": "GTE",
+	"LT":  "LT",
+	"LTE": "LTE",
+}
+
+// mysqlNotificationGroupRecord is a notification group as the API returns it.
+// The SDK models recipients that the v3.0 API does not have, so notification
+// groups go through mysqlAPI.
+type mysqlNotificationGroupRecord struct {
+	NotificationGroupID   string                       `json:"notificationGroupId"`
+	NotificationGroupName string                       `json:"notificationGroupName"`
+	NotifyEmail           bool                         `json:"notifyEmail"`
+	NotifySms             bool                         `json:"notifySms"`
+	IsEnabled             bool                         `json:"isEnabled"`
+	DBInstances           []mysqlNotificationInstance  `json:"dbInstances"`
+	UserGroups            []mysqlNotificationUserGroup `json:"userGroups"`
+	CreatedYmdt           string                       `json:"createdYmdt"`
+	UpdatedYmdt           string                       `json:"updatedYmdt"`
+}
+
+type mysqlNotificationInstance struct {
+	DBInstanceID   string `json:"dbInstanceId"`
+	DBInstanceName string `json:"dbInstanceName"`
+}
+
+type mysqlNotificationUserGroup struct {
+	UserGroupID   string `json:"userGroupId"`
+	UserGroupName string `json:"userGroupName"`
+}
+
+// mysqlNotificationGroupResponse accepts the group both flat and nested
+// under notificationGroup
+type mysqlNotificationGroupResponse struct {
+	mysqlNotificationGroupRecord
+	Nested *mysqlNotificationGroupRecord `json:"notificationGroup"`
+}
+
+func (r mysqlNotificationGroupResponse) group() mysqlNotificationGroupRecord {
+	if r.Nested != nil && r.Nested.NotificationGroupID != "" {
+		return *r.Nested
+	}
+	return r.mysqlNotificationGroupRecord
+}
+
+// mysqlWatchdog is a watch rule as the API returns it
+type mysqlWatchdog struct {
+	WatchdogID         string  `json:"watchdogId,omitempty"`
+	IsEnabled          bool    `json:"isEnabled"`
+	MetricName         string  `json:"metricName"`
+	ComparisonOperator string  `json:"comparisonOperator"`
+	Threshold          float64 `json:"threshold"`
+	Duration           int     `json:"duration"`
+}
+
+// MySQLUserGroupRef identifies a user group that receives notifications
+type MySQLUserGroupRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// MySQLWatchRule is a metric threshold that triggers a notification
+type MySQLWatchRule struct {
+	ID              string  `json:"id"`
+	Metric          string  `json:"metric"`
+	Comparison      string  `json:"comparison"`
+	Threshold       float64 `json:"threshold"`
+	DurationMinutes int     `json:"duration_minutes"`
+	Enabled         bool    `json:"enabled"`
+}
+
+// MySQLNotificationGroup represents a notification group
+type MySQLNotificationGroup struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Enabled     bool                `json:"enabled"`
+	NotifyEmail bool                `json:"notify_email"`
+	NotifySMS   bool                `json:"notify_sms"`
+	Instances   []MySQLInstanceRef  `json:"instances,omitempty"`
+	UserGroups  []MySQLUserGroupRef `json:"user_groups,omitempty"`
+	WatchRules  []MySQLWatchRule    `json:"watch_rules,omitempty"`
+	Created     string              `json:"created,omitempty"`
+	Updated     string              `json:"updated,omitempty"`
+}
+
+// MySQLWatchRuleInput describes a watch rule
+type MySQLWatchRuleInput struct {
+	Metric          string  `json:"metric" jsonschema_description:"cpu, memory, storage, connections, qps, replication_delay, slow_queries, or a measure name from nhn_mysql_get_metrics"`
+	Comparison      string  `json:"comparison" jsonschema_description:"One of >, >=, <, <= (or GT, GTE, LT, LTE)"`
+	Threshold       float64 `json:"threshold" jsonschema_description:"Value the metric is compared with, in the metric's unit (percent for cpu, memory and storage)"`
+	DurationMinutes int     `json:"duration_minutes,omitempty" jsonschema_description:"How long the condition must hold before notifying (default 5)"`
+	Disabled        bool    `json:"disabled,omitempty" jsonschema_description:"Create the rule switched off"`
+}
+
+type ListMySQLNotificationGroupsInput struct{}
+
+// ListMySQLNotificationGroupsOutput - output for listing notification groups
+type ListMySQLNotificationGroupsOutput struct {
+	NotificationGroups []MySQLNotificationGroup `json:"notification_groups"`
+	Count              int                      `json:"count"`
+}
+
+type GetMySQLNotificationGroupInput struct {
+	NotificationGroupID string `json:"notification_group_id" jsonschema_description:"The ID of the notification group"`
+}
+
+type CreateMySQLNotificationGroupInput struct {
+	Name         string                `json:"name" jsonschema_description:"Notification group name"`
+	NotifyEmail  *bool                 `json:"notify_email,omitempty" jsonschema_description:"Send notifications by email (default true)"`
+	NotifySMS    bool                  `json:"notify_sms,omitempty" jsonschema_description:"Send notifications by SMS"`
+	Disabled     bool                  `json:"disabled,omitempty" jsonschema_description:"Create the group switched off"`
+	InstanceIDs  []string              `json:"instance_ids,omitempty" jsonschema_description:"Instances whose events and metrics are watched"`
+	UserGroupIDs []string              `json:"user_group_ids,omitempty" jsonschema_description:"User groups that receive the notifications, from nhn_mysql_list_user_groups"`
+	WatchRules   []MySQLWatchRuleInput `json:"watch_rules,omitempty" jsonschema_description:"Metric thresholds to watch, e.g. cpu > 80 for 5 minutes"`
+}
+
+type UpdateMySQLNotificationGroupInput struct {
+	NotificationGroupID string   `json:"notification_group_id" jsonschema_description:"The ID of the notification group"`
+	Name                string   `json:"name,omitempty" jsonschema_description:"New name (optional)"`
+	NotifyEmail         *bool    `json:"notify_email,omitempty" jsonschema_description:"Send notifications by email (optional)"`
+	NotifySMS           *bool    `json:"notify_sms,omitempty" jsonschema_description:"Send notifications by SMS (optional)"`
+	Enabled             *bool    `json:"enabled,omitempty" jsonschema_description:"Switch the group on or off (optional)"`
+	AttachInstanceIDs   []string `json:"attach_instance_ids,omitempty" jsonschema_description:"Instances to add to the group"`
+	DetachInstanceIDs   []string `json:"detach_instance_ids,omitempty" jsonschema_description:"Instances to remove from the group"`
+	UserGroupIDs        []string `json:"user_group_ids,omitempty" jsonschema_description:"Replace the receiving user groups (optional)"`
+}
+
+type DeleteMySQLNotificationGroupInput struct {
+	NotificationGroupID string `json:"notification_group_id" jsonschema_description:"The ID of the notification group"`
+	ConfirmName         string `json:"confirm_name" jsonschema_description:"The group name, repeated to confirm deletion"`
+}
+
+type CreateMySQLWatchRuleInput struct {
+	NotificationGroupID string              `json:"notification_group_id" jsonschema_description:"The ID of the notification group"`
+	Rule                MySQLWatchRuleInput `json:"rule" jsonschema_description:"The watch rule"`
+}
+
+type UpdateMySQLWatchRuleInput struct {
+	NotificationGroupID string              `json:"notification_group_id" jsonschema_description:"The ID of the notification group"`
+	RuleID              string              `json:"rule_id" jsonschema_description:"The ID of the watch rule to replace"`
+	Rule                MySQLWatchRuleInput `json:"rule" jsonschema_description:"The new watch rule"`
+}
+
+type DeleteMySQLWatchRuleInput struct {
+	NotificationGroupID string `json:"notification_group_id" jsonschema_description:"The ID of the notification group"`
+	RuleID              string `json:"rule_id" jsonschema_description:"The ID of the watch rule"`
+}
+
+type ListMySQLUserGroupsInput struct{}
+
+// ListMySQLUserGroupsOutput - output for listing user groups
+type ListMySQLUserGroupsOutput struct {
+	UserGroups []MySQLUserGroupRef `json:"user_groups"`
+	Count      int                 `json:"count"`
+}
+
+// MySQLNotificationGroupChangeOutput - output for notification group and watch rule changes
+type MySQLNotificationGroupChangeOutput struct {
+	NotificationGroupID string   `json:"notification_group_id"`
+	RuleIDs             []string `json:"rule_ids,omitempty"`
+	Action              string   `json:"action"`
+	Warnings            []string `json:"warnings,omitempty"`
+}
+
+func registerMySQLNotificationTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_list_notification_groups",
+		Description: "List NHN Cloud RDS MySQL notification groups.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLNotificationGroupsInput]) (*mcp.CallToolResultFor[ListMySQLNotificationGroupsOutput], error) {
+		out, err := listMySQLNotificationGroups(ctx, cfg)
+		if err != nil {
+			return &mcp.CallToolResultFor[ListMySQLNotificationGroupsOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[ListMySQLNotificationGroupsOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Found %d notification groups", out.Count)}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_get_notification_group",
+		Description: "Get an NHN Cloud RDS MySQL notification group with its instances, receiving user groups and watch rules.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[GetMySQLNotificationGroupInput]) (*mcp.CallToolResultFor[MySQLNotificationGroup], error) {
+		out, err := getMySQLNotificationGroup(ctx, cfg, params.Arguments.NotificationGroupID)
+		if err != nil {
+			return &mcp.CallToolResultFor[MySQLNotificationGroup]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		text := fmt.Sprintf("Notification group %s: %d instances, %d user groups, %d watch rules",
+			out.Name, len(out.Instances), len(out.UserGroups), len(out.WatchRules))
+		if len(out.UserGroups) == 0 {
+			text += "\nWarning: no user group receives these notifications"
+		}
+		return &mcp.CallToolResultFor[MySQLNotificationGroup]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_create_notification_group",
+		Description: "Create an NHN Cloud RDS MySQL notification group for a set of instances and user groups, optionally with watch rules on metrics such as CPU, storage or connections.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[CreateMySQLNotificationGroupInput]) (*mcp.CallToolResultFor[MySQLNotificationGroupChangeOutput], error) {
+		out, err := createMySQLNotificationGroup(ctx, cfg, params.Arguments)
+		return mysqlNotificationGroupChangeResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_update_notification_group",
+		Description: "Update an NHN Cloud RDS MySQL notification group: rename it, switch it or its email/SMS delivery on or off, attach or detach instances, or replace its user groups.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[UpdateMySQLNotificationGroupInput]) (*mcp.CallToolResultFor[MySQLNotificationGroupChangeOutput], error) {
+		out, err := updateMySQLNotificationGroup(ctx, cfg, params.Arguments)
+		return mysqlNotificationGroupChangeResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_delete_notification_group",
+		Description: "Delete an NHN Cloud RDS MySQL notification group and its watch rules. confirm_name must repeat the group name.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[DeleteMySQLNotificationGroupInput]) (*mcp.CallToolResultFor[MySQLNotificationGroupChangeOutput], error) {
+		out, err := deleteMySQLNotificationGroup(ctx, cfg, params.Arguments)
+		return mysqlNotificationGroupChangeResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_create_watch_rule",
+		Description: "Add a watch rule to an NHN Cloud RDS MySQL notification group, e.g. notify when storage > 85 for 10 minutes.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[CreateMySQLWatchRuleInput]) (*mcp.CallToolResultFor[MySQLNotificationGroupChangeOutput], error) {
+		out, err := createMySQLWatchRule(ctx, cfg, params.Arguments)
+		return mysqlNotificationGroupChangeResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_update_watch_rule",
+		Description: "Replace a watch rule of an NHN Cloud RDS MySQL notification group.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[UpdateMySQLWatchRuleInput]) (*mcp.CallToolResultFor[MySQLNotificationGroupChangeOutput], error) {
+		out, err := updateMySQLWatchRule(ctx, cfg, params.Arguments)
+		return mysqlNotificationGroupChangeResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_delete_watch_rule",
+		Description: "Remove a watch rule from an NHN Cloud RDS MySQL notification group.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[DeleteMySQLWatchRuleInput]) (*mcp.CallToolResultFor[MySQLNotificationGroupChangeOutput], error) {
+		out, err := deleteMySQLWatchRule(ctx, cfg, params.Arguments)
+		return mysqlNotificationGroupChangeResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_list_user_groups",
+		Description: "List the NHN Cloud RDS MySQL user groups that notification groups can send to.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLUserGroupsInput]) (*mcp.CallToolResultFor[ListMySQLUserGroupsOutput], error) {
+		out, err := listMySQLUserGroups(ctx, cfg)
+		if err != nil {
+			return &mcp.CallToolResultFor[ListMySQLUserGroupsOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[ListMySQLUserGroupsOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Found %d user groups", out.Count)}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func mysqlNotificationGroupChangeResult(out MySQLNotificationGroupChangeOutput, err error) (*mcp.CallToolResultFor[MySQLNotificationGroupChangeOutput], error) {
+	if err != nil {
+		return &mcp.CallToolResultFor[MySQLNotificationGroupChangeOutput]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+			IsError: true,
+		}, nil
+	}
+
+	text := fmt.Sprintf("Notification group %s: %s", out.NotificationGroupID, out.Action)
+	for _, w := range out.Warnings {
+		text += "\nWarning: " + w
+	}
+	return &mcp.CallToolResultFor[MySQLNotificationGroupChangeOutput]{
+		Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+		StructuredContent: out,
+	}, nil
+}
+
+func listMySQLNotificationGroups(ctx context.Context, cfg *config.Config) (ListMySQLNotificationGroupsOutput, error) {
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return ListMySQLNotificationGroupsOutput{}, err
+	}
+
+	var result struct {
+		NotificationGroups []mysqlNotificationGroupRecord `json:"notificationGroups"`
+	}
+	if err := api.get(ctx, "/notification-groups", nil, &result); err != nil {
+		return ListMySQLNotificationGroupsOutput{}, fmt.Errorf("failed to list notification groups: %w", err)
+	}
+
+	groups := make([]MySQLNotificationGroup, 0, len(result.NotificationGroups))
+	for _, g := range result.NotificationGroups {
+		groups = append(groups, newMySQLNotificationGroup(g, nil))
+	}
+	return ListMySQLNotificationGroupsOutput{NotificationGroups: groups, Count: len(groups)}, nil
+}
+
+func getMySQLNotificationGroup(ctx context.Context, cfg *config.Config, groupID string) (MySQLNotificationGroup, error) {
+	if groupID == "" {
+		return MySQLNotificationGroup{}, fmt.Errorf("notification_group_id is required")
+	}
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return MySQLNotificationGroup{}, err
+	}
+
+	g, err := fetchMySQLNotificationGroup(ctx, api, groupID)
+	if err != nil {
+		return MySQLNotificationGroup{}, err
+	}
+	rules, err := fetchMySQLWatchdogs(ctx, api, groupID)
+	if err != nil {
+		return MySQLNotificationGroup{}, err
+	}
+	return newMySQLNotificationGroup(g, rules), nil
+}
+
+func createMySQLNotificationGroup(ctx context.Context, cfg *config.Config, in CreateMySQLNotificationGroupInput) (MySQLNotificationGroupChangeOutput, error) {
+	if strings.TrimSpace(in.Name) == "" {
+		return MySQLNotificationGroupChangeOutput{}, fmt.Errorf("name is required")
+	}
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return MySQLNotificationGroupChangeOutput{}, err
+	}
+
+	// Resolve every rule before creating anything so a typo doesn't leave a
+	// half-configured group behind
+	var rules []mysqlWatchdog
+	if len(in.WatchRules) > 0 {
+		measures, err := listMySQLMeasures(ctx, api)
+		if err != nil {
+			return MySQLNotificationGroupChangeOutput{}, err
+		}
+		var problems validationErrors
+		for i, r := range in.WatchRules {
+			rule, err := buildMySQLWatchdog(measures, r)
+			if err != nil {
+				problems.addf("watch_rules[%d]: %v", i, err)
+				continue
+			}
+			rules = append(rules, rule)
+		}
+		if err := problems.err(); err != nil {
+			return MySQLNotificationGroupChangeOutput{}, err
+		}
+	}
+	if err := checkMySQLInstancesExist(ctx, api, in.InstanceIDs); err != nil {
+		return MySQLNotificationGroupChangeOutput{}, err
+	}
+
+	notifyEmail := in.NotifyEmail == nil || *in.NotifyEmail
+	var created struct {
+		NotificationGroupID string `json:"notificationGroupId"`
+	}
+	err = api.post(ctx, "/notification-groups", map[string]any{
+		"notificationGroupName": in.Name,
+		"notifyEmail":           notifyEmail,
+		"notifySms":             in.NotifySMS,
+		"isEnabled":             !in.Disabled,
+		"dbInstanceIds":         nonNilStrings(in.InstanceIDs),
+		"userGroupIds":          nonNilStrings(in.UserGroupIDs),
+	}, &created)
+	if err != nil {
+		return MySQLNotificationGroupChangeOutput{}, fmt.Errorf("failed to create notification group: %w", err)
+	}
+
+	out := MySQLNotificationGroupChangeOutput{NotificationGroupID: created.NotificationGroupID}
+	for i, rule := range rules {
+		id, err := postMySQLWatchdog(ctx, api, created.NotificationGroupID, rule)
+		if err != nil {
+			return out, fmt.Errorf("notification group %s was created, but watch rule %d of %d failed (%d added): %w",
+				created.NotificationGroupID, i+1, len(rules), len(out.RuleIDs), err)
+		}
+		out.RuleIDs = append(out.RuleIDs, id)
+	}
+
+	out.Action = fmt.Sprintf("created with %d instances and %d watch rules", len(in.InstanceIDs), len(out.RuleIDs))
+	if len(in.UserGroupIDs) == 0 {
+		out.Warnings = append(out.Warnings, "no user group receives these notifications; add one with nhn_mysql_update_notification_group")
+	}
+	if !notifyEmail && !in.NotifySMS {
+		out.Warnings = append(out.Warnings, "both email and SMS delivery are off")
+	}
+	return out, nil
+}
+
+func updateMySQLNotificationGroup(ctx context.Context, cfg *config.Config, in UpdateMySQLNotificationGroupInput) (MySQLNotificationGroupChangeOutput, error) {
+	if in.NotificationGroupID == "" {
+		return MySQLNotificationGroupChangeOutput{}, fmt.Errorf("notification_group_id is required")
+	}
+	if in.Name == "" && in.NotifyEmail == nil && in.NotifySMS == nil && in.Enabled == nil &&
+		len(in.AttachInstanceIDs) == 0 && len(in.DetachInstanceIDs) == 0 && in.UserGroupIDs == nil {
+		return MySQLNotificationGroupChangeOutput{}, fmt.Errorf("nothing to update")
+	}
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return MySQLNotificationGroupChangeOutput{}, err
+	}
+
+	current, err := fetchMySQLNotificationGroup(ctx, api, in.NotificationGroupID)
+	if err != nil {
+		return MySQLNotificationGroupChangeOutput{}, err
+	}
+	if err := checkMySQLInstancesExist(ctx, api, in.AttachInstanceIDs); err != nil {
+		return MySQLNotificationGroupChangeOutput{}, err
+	}
+
+	// The API replaces the instance and user group lists, so start from the
+	// current ones
+	instanceIDs := make([]string, 0, len(current.DBInstances)+len(in.AttachInstanceIDs))
+	for _, inst := range current.DBInstances {
+		if !slices.Contains(in.DetachInstanceIDs, inst.DBInstanceID) {
+			instanceIDs = append(instanceIDs, inst.DBInstanceID)
+		}
+	}
+	for _, id := range in.AttachInstanceIDs {
+		if !slices.Contains(instanceIDs, id) {
+			instanceIDs = append(instanceIDs, id)
+		}
+	}
+	userGroupIDs := in.UserGroupIDs
+	if userGroupIDs == nil {
+		userGroupIDs = []string{}
+		for _, ug := range current.UserGroups {
+			userGroupIDs = append(userGroupIDs, ug.UserGroupID)
+		}
+	}
+
+	body := map[string]any{
+		"notificationGroupName": current.NotificationGroupName,
+		"notifyEmail":           current.NotifyEmail,
+		"notifySms":             current.NotifySms,
+		"isEnabled":             current.IsEnabled,
+		"dbInstanceIds":         instanceIDs,
+		"userGroupIds":          userGroupIDs,
+	}
+	if in.Name != "" {
+		body["notificationGroupName"] = in.Name
+	}
+	if in.NotifyEmail != nil {
+		body["notifyEmail"] = *in.NotifyEmail
+	}
+	if in.NotifySMS != nil {
+		body["notifySms"] = *in.NotifySMS
+	}
+	if in.Enabled != nil {
+		body["isEnabled"] = *in.Enabled
+	}
+	if err := api.put(ctx, "/notification-groups/"+url.PathEscape(in.NotificationGroupID), body, nil); err != nil {
+		return MySQLNotificationGroupChangeOutput{}, fmt.Errorf("failed to update notification group: %w", err)
+	}
+
+	out := MySQLNotificationGroupChangeOutput{
+		NotificationGroupID: in.NotificationGroupID,
+		Action:              fmt.Sprintf("updated; now watching %d instances for %d user groups", len(instanceIDs), len(userGroupIDs)),
+	}
+	for _, id := range in.DetachInstanceIDs {
+		if !slices.ContainsFunc(current.DBInstances, func(inst mysqlNotificationInstance) bool { return inst.DBInstanceID == id }) {
+			out.Warnings = append(out.Warnings, fmt.Sprintf("instance %s was not in the group", id))
+		}
+	}
+	if len(userGroupIDs) == 0 {
+		out.Warnings = append(out.Warnings, "no user group receives these notifications")
+	}
+	return out, nil
+}
+
+func deleteMySQLNotificationGroup(ctx context.Context, cfg *config.Config, in DeleteMySQLNotificationGroupInput) (MySQLNotificationGroupChangeOutput, error) {
+	if in.NotificationGroupID == "" {
+		return MySQLNotificationGroupChangeOutput{}, fmt.Errorf("notification_group_id is required")
+	}
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return MySQLNotificationGroupChangeOutput{}, err
+	}
+
+	current, err := fetchMySQLNotificationGroup(ctx, api, in.NotificationGroupID)
+	if err != nil {
+		return MySQLNotificationGroupChangeOutput{}, err
+	}
+	if err := checkMySQLConfirmName("deletion", "notification group "+in.NotificationGroupID, in.ConfirmName, current.NotificationGroupName); err != nil {
+		return MySQLNotificationGroupChangeOutput{}, err
+	}
+	if err := api.delete(ctx, "/notification-groups/"+url.PathEscape(in.NotificationGroupID), nil); err != nil {
+		return MySQLNotificationGroupChangeOutput{}, fmt.Errorf("failed to delete notification group: %w", err)
+	}
+
+	out := MySQLNotificationGroupChangeOutput{NotificationGroupID: in.NotificationGroupID, Action: "deleted"}
+	if n := len(current.DBInstances); n > 0 {
+		out.Warnings = append(out.Warnings, fmt.Sprintf("%d instances no longer send notifications through this group", n))
+	}
+	return out, nil
+}
+
+func createMySQLWatchRule(ctx context.Context, cfg *config.Config, in CreateMySQLWatchRuleInput) (MySQLNotificationGroupChangeOutput, error) {
+	if in.NotificationGroupID == "" {
+		return MySQLNotificationGroupChangeOutput{}, fmt.Errorf("notification_group_id is required")
+	}
+	api, rule, err := prepareMySQLWatchdog(ctx, cfg, in.Rule)
+	if err != nil {
+		return MySQLNotificationGroupChangeOutput{}, err
+	}
+
+	id, err := postMySQLWatchdog(ctx, api, in.NotificationGroupID, rule)
+	if err != nil {
+		return MySQLNotificationGroupChangeOutput{}, err
+	}
+	return MySQLNotificationGroupChangeOutput{
+		NotificationGroupID: in.NotificationGroupID,
+		RuleIDs:             []string{id},
+		Action:              "watch rule added: " + describeMySQLWatchdog(rule),
+	}, nil
+}
+
+func updateMySQLWatchRule(ctx context.Context, cfg *config.Config, in UpdateMySQLWatchRuleInput) (MySQLNotificationGroupChangeOutput, error) {
+	if in.NotificationGroupID == "" || in.RuleID == "" {
+		return MySQLNotificationGroupChangeOutput{}, fmt.Errorf("notification_group_id and rule_id are required")
+	}
+	api, rule, err := prepareMySQLWatchdog(ctx, cfg, in.Rule)
+	if err != nil {
+		return MySQLNotificationGroupChangeOutput{}, err
+	}
+
+	if err := api.put(ctx, mysqlWatchdogPath(in.NotificationGroupID, in.RuleID), rule, nil); err != nil {
+		return MySQLNotificationGroupChangeOutput{}, fmt.Errorf("failed to update watch rule: %w", err)
+	}
+	return MySQLNotificationGroupChangeOutput{
+		NotificationGroupID: in.NotificationGroupID,
+		RuleIDs:             []string{in.RuleID},
+		Action:              "watch rule updated: " + describeMySQLWatchdog(rule),
+	}, nil
+}
+
+func deleteMySQLWatchRule(ctx context.Context, cfg *config.Config, in DeleteMySQLWatchRuleInput) (MySQLNotificationGroupChangeOutput, error) {
+	if in.NotificationGroupID == "" || in.RuleID == "" {
+		return MySQLNotificationGroupChangeOutput{}, fmt.Errorf("notification_group_id and rule_id are required")
+	}
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return MySQLNotificationGroupChangeOutput{}, err
+	}
+
+	if err := api.delete(ctx, mysqlWatchdogPath(in.NotificationGroupID, in.RuleID), nil); err != nil {
+		return MySQLNotificationGroupChangeOutput{}, fmt.Errorf("failed to delete watch rule: %w", err)
+	}
+	return MySQLNotificationGroupChangeOutput{
+		NotificationGroupID: in.NotificationGroupID,
+		RuleIDs:             []string{in.RuleID},
+		Action:              "watch rule deleted",
+	}, nil
+}
+
+func listMySQLUserGroups(ctx context.Context, cfg *config.Config) (ListMySQLUserGroupsOutput, error) {
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return ListMySQLUserGroupsOutput{}, err
+	}
+
+	var result struct {
+		UserGroups []mysqlNotificationUserGroup `json:"userGroups"`
+	}
+	if err := api.get(ctx, "/user-groups", nil, &result); err != nil {
+		return ListMySQLUserGroupsOutput{}, fmt.Errorf("failed to list user groups: %w", err)
+	}
+
+	groups := make([]MySQLUserGroupRef, 0, len(result.UserGroups))
+	for _, g := range result.UserGroups {
+		groups = append(groups, MySQLUserGroupRef{ID: g.UserGroupID, Name: g.UserGroupName})
+	}
+	return ListMySQLUserGroupsOutput{UserGroups: groups, Count: len(groups)}, nil
+}
+
+func prepareMySQLWatchdog(ctx context.Context, cfg *config.Config, in MySQLWatchRuleInput) (*mysqlAPI, mysqlWatchdog, error) {
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return nil, mysqlWatchdog{}, err
+	}
+	measures, err := listMySQLMeasures(ctx, api)
+	if err != nil {
+		return nil, mysqlWatchdog{}, err
+	}
+	rule, err := buildMySQLWatchdog(measures, in)
+	if err != nil {
+		return nil, mysqlWatchdog{}, err
+	}
+	return api, rule, nil
+}
+
+// buildMySQLWatchdog validates a watch rule and resolves its metric to an
+// available measure name
+func buildMySQLWatchdog(measures []mysqlMeasure, in MySQLWatchRuleInput) (mysqlWatchdog, error) {
+	var problems validationErrors
+
+	metric := resolveMySQLMeasure(measures, in.Metric)
+	unit := ""
+	if metric == "" {
+		problems.addf("metric %q is not available; use a friendly name such as cpu, storage or connections, or a measure name from nhn_mysql_get_metrics", in.Metric)
+	} else {
+		for _, m := range measures {
+			if m.MeasureName == metric {
+				unit = m.Unit
+			}
+		}
+	}
+	comparison, ok := mysqlWatchComparisons[strings.ToUpper(strings.TrimSpace(in.Comparison))]
+	if !ok {
+		problems.addf("comparison %q must be one of >, >=, <, <=", in.Comparison)
+	}
+	if in.Threshold < 0 {
+		problems.addf("threshold %g must not be negative", in.Threshold)
+	}
+	if unit == "%" && in.Threshold > 100 {
+		problems.addf("threshold %g is above 100 for %s, which is a percentage", in.Threshold, metric)
+	}
+	duration := in.DurationMinutes
+	if duration == 0 {
+		duration = mysqlWatchDefaultDuration
+	}
+	if duration < 1 || duration > mysqlWatchMaxDuration {
+		problems.addf("duration_minutes %d must be between 1 and %d", in.DurationMinutes, mysqlWatchMaxDuration)
+	}
+	if err := problems.err(); err != nil {
+		return mysqlWatchdog{}, err
+	}
+
+	return mysqlWatchdog{
+		IsEnabled:          !in.Disabled,
+		MetricName:         metric,
+		ComparisonOperator: comparison,
+		Threshold:          in.Threshold,
+		Duration:           duration,
+	}, nil
+}
+
+func describeMySQLWatchdog(w mysqlWatchdog) string {
+	return fmt.Sprintf("%s %s %g for %d minutes", w.MetricName, w.ComparisonOperator, w.Threshold, w.Duration)
+}
+
+func mysqlWatchdogPath(groupID, ruleID string) string {
+	path := "/notification-groups/" + url.PathEscape(groupID) + "/watchdogs"
+	if ruleID != "" {
+		path += "/" + url.PathEscape(ruleID)
+	}
+	return path
+}
+
+func postMySQLWatchdog(ctx context.Context, api *mysqlAPI, groupID string, rule mysqlWatchdog) (string, error) {
+	var created struct {
+		WatchdogID string `json:"watchdogId"`
+	}
+	if err := api.post(ctx, mysqlWatchdogPath(groupID, ""), rule, &created); err != nil {
+		return "", fmt.Errorf("failed to add watch rule %s: %w", describeMySQLWatchdog(rule), err)
+	}
+	return created.WatchdogID, nil
+}
+
+func fetchMySQLNotificationGroup(ctx context.Context, api *mysqlAPI, groupID string) (mysqlNotificationGroupRecord, error) {
+	var resp mysqlNotificationGroupResponse
+	if err := api.get(ctx, "/notification-groups/"+url.PathEscape(groupID), nil, &resp); err != nil {
+		return mysqlNotificationGroupRecord{}, fmt.Errorf("failed to get notification group: %w", err)
+	}
+	return resp.group(), nil
+}
+
+func fetchMySQLWatchdogs(ctx context.Context, api *mysqlAPI, groupID string) ([]mysqlWatchdog, error) {
+	var result struct {
+		Watchdogs []mysqlWatchdog `json:"watchdogs"`
+	}
+	if err := api.get(ctx, mysqlWatchdogPath(groupID, ""), nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to list watch rules: %w", err)
+	}
+	return result.Watchdogs, nil
+}
+
+// checkMySQLInstancesExist reports every ID that is not a MySQL instance
+func checkMySQLInstancesExist(ctx context.Context, api *mysqlAPI, instanceIDs []string) error {
+	if len(instanceIDs) == 0 {
+		return nil
+	}
+	records, err := fetchMySQLInstanceRecords(ctx, api)
+	if err != nil {
+		return err
+	}
+	var problems validationErrors
+	for _, id := range instanceIDs {
+		if !slices.ContainsFunc(records, func(r mysqlInstanceRecord) bool { return r.DBInstanceID == id }) {
+			problems.addf("instance %s not found", id)
+		}
+	}
+	return problems.err()
+}
+
+func newMySQLNotificationGroup(g mysqlNotificationGroupRecord, rules []mysqlWatchdog) MySQLNotificationGroup {
+	out := MySQLNotificationGroup{
+		ID:          g.NotificationGroupID,
+		Name:        g.NotificationGroupName,
+		Enabled:     g.IsEnabled,
+		NotifyEmail: g.NotifyEmail,
+		NotifySMS:   g.NotifySms,
+		Created:     g.CreatedYmdt,
+		Updated:     g.UpdatedYmdt,
+	}
+	for _, inst := range g.DBInstances {
+		out.Instances = append(out.Instances, MySQLInstanceRef{ID: inst.DBInstanceID, Name: inst.DBInstanceName})
+	}
+	for _, ug := range g.UserGroups {
+		out.UserGroups = append(out.UserGroups, MySQLUserGroupRef{ID: ug.UserGroupID, Name: ug.UserGroupName})
+	}
+	for _, r := range rules {
+		out.WatchRules = append(out.WatchRules, MySQLWatchRule{
+			ID:              r.WatchdogID,
+			Metric:          r.MetricName,
+			Comparison:      r.ComparisonOperator,
+			Threshold:       r.Threshold,
+			DurationMinutes: r.Duration,
+			Enabled:         r.IsEnabled,
+		})
+	}
+	return out
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
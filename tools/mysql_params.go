@@ -0,0 +1,572 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/rds/mysql"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// mysqlFixedUpdateTypes mark parameters whose value cannot be changed
+var mysqlFixedUpdateTypes = []string{"CONSTANT", "FIXED"}
+
+// mysqlSetParameters hold a comma-separated set of their allowed values
+// rather than a single one
+var mysqlSetParameters = []string{"sql_mode", "log_output", "tls_version", "admin_tls_version"}
+
+// mysqlAllowedRangePattern matches numeric allowed values such as "1-65535"
+var mysqlAllowedRangePattern = regexp.MustCompile(`^\s*(-?\d+(?:\.\d+)?)\s*-\s*(-?\d+(?:\.\d+)?)\s*$`)
+
+type ListMySQLParameterGroupsInput struct {
+	DBVersion string `json:"db_version,omitempty" jsonschema_description:"Only show groups for this DB version, e.g. MYSQL_V8032 (optional)"`
+}
+
+// MySQLParameterGroup represents a MySQL parameter group
+type MySQLParameterGroup struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	DBVersion   string `json:"db_version"`
+	Status      string `json:"status"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// ListMySQLParameterGroupsOutput - output for listing parameter groups
+type ListMySQLParameterGroupsOutput struct {
+	ParameterGroups []MySQLParameterGroup `json:"parameter_groups"`
+	Count           int                   `json:"count"`
+}
+
+type GetMySQLParameterGroupInput struct {
+	ParameterGroupID string `json:"parameter_group_id" jsonschema_description:"The ID of the parameter group"`
+	NameFilter       string `json:"name_filter,omitempty" jsonschema_description:"Only show parameters whose name contains this text (optional)"`
+	ModifiedOnly     bool   `json:"modified_only,omitempty" jsonschema_description:"Only show parameters that differ from their default"`
+}
+
+// MySQLParameter represents one parameter of a parameter group
+type MySQLParameter struct {
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+	Default    string `json:"default"`
+	Allowed    string `json:"allowed"`
+	UpdateType string `json:"update_type"`
+	ApplyType  string `json:"apply_type"`
+	Modified   bool   `json:"modified"`
+}
+
+// GetMySQLParameterGroupOutput - output for showing a parameter group
+type GetMySQLParameterGroupOutput struct {
+	ParameterGroup MySQLParameterGroup `json:"parameter_group"`
+	Parameters     []MySQLParameter    `json:"parameters"`
+	Count          int                 `json:"count"`
+}
+
+type ModifyMySQLParametersInput struct {
+	ParameterGroupID string            `json:"parameter_group_id" jsonschema_description:"The ID of the parameter group"`
+	Parameters       map[string]string `json:"parameters" jsonschema_description:"Parameter names mapped to their new values"`
+	DryRun           bool              `json:"dry_run,omitempty" jsonschema_description:"Only validate and report the changes without applying them"`
+}
+
+// MySQLParameterChange is a single parameter value change
+type MySQLParameterChange struct {
+	Name      string `json:"name"`
+	Before    string `json:"before"`
+	After     string `json:"after"`
+	ApplyType string `json:"apply_type"`
+}
+
+// ModifyMySQLParametersOutput - output for modifying parameters
+type ModifyMySQLParametersOutput struct {
+	ParameterGroupID string                 `json:"parameter_group_id"`
+	Changes          []MySQLParameterChange `json:"changes"`
+	Unchanged        []string               `json:"unchanged,omitempty"`
+	DryRun           bool                   `json:"dry_run"`
+}
+
+type ApplyMySQLParameterGroupInput struct {
+	ParameterGroupID  string   `json:"parameter_group_id" jsonschema_description:"The ID of the parameter group to assign"`
+	InstanceIDs       []string `json:"instance_ids" jsonschema_description:"IDs of the MySQL instances to assign the group to"`
+	UseOnlineFailover bool     `json:"use_online_failover,omitempty" jsonschema_description:"For high availability instances, restart via failover to reduce downtime"`
+}
+
+// MySQLParameterGroupAssignment is the result of assigning a group to one instance
+type MySQLParameterGroupAssignment struct {
+	InstanceID string `json:"instance_id"`
+	Name       string `json:"name,omitempty"`
+	Previous   string `json:"previous_parameter_group_id,omitempty"`
+	Result     string `json:"result"`
+	JobID      string `json:"job_id,omitempty"`
+}
+
+// ApplyMySQLParameterGroupOutput - output for assigning a parameter group
+type ApplyMySQLParameterGroupOutput struct {
+	ParameterGroupID string                          `json:"parameter_group_id"`
+	Instances        []MySQLParameterGroupAssignment `json:"instances"`
+}
+
+type DiffMySQLParameterGroupsInput struct {
+	ParameterGroupID      string `json:"parameter_group_id" jsonschema_description:"The ID of the parameter group"`
+	OtherParameterGroupID string `json:"other_parameter_group_id,omitempty" jsonschema_description:"The group to compare with; omit to compare against engine defaults"`
+}
+
+// MySQLParameterDifference is a parameter whose values differ
+type MySQLParameterDifference struct {
+	Name  string `json:"name"`
+	Left  string `json:"left"`
+	Right string `json:"right"`
+}
+
+// DiffMySQLParameterGroupsOutput - output for diffing parameter groups
+type DiffMySQLParameterGroupsOutput struct {
+	Left        string                     `json:"left"`
+	Right       string                     `json:"right"`
+	Differences []MySQLParameterDifference `json:"differences"`
+	OnlyInLeft  []string                   `json:"only_in_left,omitempty"`
+	OnlyInRight []string                   `json:"only_in_right,omitempty"`
+}
+
+func registerMySQLParameterTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_list_parameter_groups",
+		Description: "List NHN Cloud RDS MySQL parameter groups, optionally only those for one DB version.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLParameterGroupsInput]) (*mcp.CallToolResultFor[ListMySQLParameterGroupsOutput], error) {
+		out, err := listMySQLParameterGroups(ctx, cfg, params.Arguments.DBVersion)
+		if err != nil {
+			return &mcp.CallToolResultFor[ListMySQLParameterGroupsOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[ListMySQLParameterGroupsOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Found %d MySQL parameter groups", out.Count)}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_get_parameter_group",
+		Description: "Show the parameters of an NHN Cloud RDS MySQL parameter group with their current value, default, allowed values and update/apply type.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[GetMySQLParameterGroupInput]) (*mcp.CallToolResultFor[GetMySQLParameterGroupOutput], error) {
+		out, err := getMySQLParameterGroup(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[GetMySQLParameterGroupOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[GetMySQLParameterGroupOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Parameter group %s (%s): %d parameters", out.ParameterGroup.Name, out.ParameterGroup.DBVersion, out.Count)}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_modify_parameters",
+		Description: "Change parameter values in an NHN Cloud RDS MySQL parameter group. Each value is checked against the parameter's allowed values, and fixed parameters are rejected. Use dry_run to preview.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ModifyMySQLParametersInput]) (*mcp.CallToolResultFor[ModifyMySQLParametersOutput], error) {
+		out, err := modifyMySQLParameters(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[ModifyMySQLParametersOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		var b strings.Builder
+		if out.DryRun {
+			fmt.Fprintf(&b, "Dry run: %d parameter changes", len(out.Changes))
+		} else {
+			fmt.Fprintf(&b, "Changed %d parameters", len(out.Changes))
+		}
+		for _, c := range out.Changes {
+			fmt.Fprintf(&b, "\n- %s: %s -> %s (%s)", c.Name, c.Before, c.After, c.ApplyType)
+		}
+		return &mcp.CallToolResultFor[ModifyMySQLParametersOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: b.String()}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_apply_parameter_group",
+		Description: "Assign an NHN Cloud RDS MySQL parameter group to instances. The group's DB version must match each instance's. Instances restart if the new group differs in parameters that require a restart.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ApplyMySQLParameterGroupInput]) (*mcp.CallToolResultFor[ApplyMySQLParameterGroupOutput], error) {
+		out, err := applyMySQLParameterGroup(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[ApplyMySQLParameterGroupOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "Parameter group %s:", out.ParameterGroupID)
+		for _, a := range out.Instances {
+			fmt.Fprintf(&b, "\n- %s: %s", a.InstanceID, a.Result)
+			if a.JobID != "" {
+				fmt.Fprintf(&b, " (job %s)", a.JobID)
+			}
+		}
+		return &mcp.CallToolResultFor[ApplyMySQLParameterGroupOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: b.String()}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_diff_parameter_groups",
+		Description: "Compare the parameters of two NHN Cloud RDS MySQL parameter groups, or of one group against the engine defaults.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[DiffMySQLParameterGroupsInput]) (*mcp.CallToolResultFor[DiffMySQLParameterGroupsOutput], error) {
+		out, err := diffMySQLParameterGroups(ctx, cfg, params.Arguments.ParameterGroupID, params.Arguments.OtherParameterGroupID)
+		if err != nil {
+			return &mcp.CallToolResultFor[DiffMySQLParameterGroupsOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "%s vs %s: %d differences", out.Left, out.Right, len(out.Differences))
+		for _, d := range out.Differences {
+			fmt.Fprintf(&b, "\n- %s: %s | %s", d.Name, d.Left, d.Right)
+		}
+		if n := len(out.OnlyInLeft) + len(out.OnlyInRight); n > 0 {
+			fmt.Fprintf(&b, "\n%d parameters exist in only one group", n)
+		}
+		return &mcp.CallToolResultFor[DiffMySQLParameterGroupsOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: b.String()}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func listMySQLParameterGroups(ctx context.Context, cfg *config.Config, dbVersion string) (ListMySQLParameterGroupsOutput, error) {
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return ListMySQLParameterGroupsOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	// The SDK's dbVersion filter is not applied reliably, so filter here
+	result, err := client.MySQL().ListParameterGroups(ctx, "")
+	if err != nil {
+		return ListMySQLParameterGroupsOutput{}, fmt.Errorf("failed to list parameter groups: %w", err)
+	}
+
+	groups := make([]MySQLParameterGroup, 0, len(result.ParameterGroups))
+	for _, g := range result.ParameterGroups {
+		if dbVersion != "" && g.DBVersion != dbVersion {
+			continue
+		}
+		groups = append(groups, newMySQLParameterGroup(g))
+	}
+
+	return ListMySQLParameterGroupsOutput{
+		ParameterGroups: groups,
+		Count:           len(groups),
+	}, nil
+}
+
+func getMySQLParameterGroup(ctx context.Context, cfg *config.Config, in GetMySQLParameterGroupInput) (GetMySQLParameterGroupOutput, error) {
+	group, err := fetchMySQLParameterGroup(ctx, cfg, in.ParameterGroupID)
+	if err != nil {
+		return GetMySQLParameterGroupOutput{}, err
+	}
+
+	filter := strings.ToLower(in.NameFilter)
+	parameters := make([]MySQLParameter, 0, len(group.Parameters))
+	for _, p := range group.Parameters {
+		if filter != "" && !strings.Contains(strings.ToLower(p.ParameterName), filter) {
+			continue
+		}
+		param := MySQLParameter{
+			Name:       p.ParameterName,
+			Value:      p.Value,
+			Default:    p.DefaultValue,
+			Allowed:    p.AllowedValue,
+			UpdateType: p.UpdateType,
+			ApplyType:  p.ApplyType,
+			Modified:   p.Value != p.DefaultValue,
+		}
+		if in.ModifiedOnly && !param.Modified {
+			continue
+		}
+		parameters = append(parameters, param)
+	}
+
+	return GetMySQLParameterGroupOutput{
+		ParameterGroup: newMySQLParameterGroup(group.ParameterGroup),
+		Parameters:     parameters,
+		Count:          len(parameters),
+	}, nil
+}
+
+func modifyMySQLParameters(ctx context.Context, cfg *config.Config, in ModifyMySQLParametersInput) (ModifyMySQLParametersOutput, error) {
+	if len(in.Parameters) == 0 {
+		return ModifyMySQLParametersOutput{}, fmt.Errorf("parameters is required")
+	}
+
+	group, err := fetchMySQLParameterGroup(ctx, cfg, in.ParameterGroupID)
+	if err != nil {
+		return ModifyMySQLParametersOutput{}, err
+	}
+	byName := make(map[string]mysql.Parameter, len(group.Parameters))
+	for _, p := range group.Parameters {
+		byName[p.ParameterName] = p
+	}
+
+	names := make([]string, 0, len(in.Parameters))
+	for name := range in.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := ModifyMySQLParametersOutput{ParameterGroupID: in.ParameterGroupID, DryRun: in.DryRun}
+	req := &mysql.ModifyParametersInput{}
+	var problems validationErrors
+	for _, name := range names {
+		value := in.Parameters[name]
+		p, ok := byName[name]
+		if !ok {
+			problems.addf("parameter %q does not exist in group %s", name, group.ParameterGroupName)
+			continue
+		}
+		if slices.Contains(mysqlFixedUpdateTypes, p.UpdateType) {
+			problems.addf("parameter %s cannot be changed (update type %s)", name, p.UpdateType)
+			continue
+		}
+		if err := checkMySQLParameterValue(name, p.AllowedValue, value); err != nil {
+			problems.addf("parameter %s: %v", name, err)
+			continue
+		}
+		if value == p.Value {
+			out.Unchanged = append(out.Unchanged, name)
+			continue
+		}
+		out.Changes = append(out.Changes, MySQLParameterChange{Name: name, Before: p.Value, After: value, ApplyType: p.ApplyType})
+		req.ModifiedParameters = append(req.ModifiedParameters, struct {
+			ParameterID string `json:"parameterId"`
+			Value       string `json:"value"`
+		}{ParameterID: p.ParameterID, Value: value})
+	}
+	if err := problems.err(); err != nil {
+		return ModifyMySQLParametersOutput{}, err
+	}
+
+	if in.DryRun || len(out.Changes) == 0 {
+		return out, nil
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return ModifyMySQLParametersOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	if _, err := client.MySQL().ModifyParameters(ctx, in.ParameterGroupID, req); err != nil {
+		return ModifyMySQLParametersOutput{}, fmt.Errorf("failed to modify parameters: %w", err)
+	}
+	return out, nil
+}
+
+// checkMySQLParameterValue validates value against an allowed value
+// description: a numeric range such as "1-65535" or a list such as "ON,OFF".
+// Values of set parameters such as sql_mode may combine several options of
+// the list. Descriptions in any other format are not checked here; the API
+// has the final say.
+func checkMySQLParameterValue(name, allowed, value string) error {
+	allowed = strings.TrimSpace(allowed)
+	if allowed == "" {
+		return nil
+	}
+
+	if m := mysqlAllowedRangePattern.FindStringSubmatch(allowed); m != nil {
+		lo, _ := strconv.ParseFloat(m[1], 64)
+		hi, _ := strconv.ParseFloat(m[2], 64)
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return fmt.Errorf("value %q must be a number in %s", value, allowed)
+		}
+		if v < lo || v > hi {
+			return fmt.Errorf("value %s is outside the allowed range %s", value, allowed)
+		}
+		return nil
+	}
+
+	list := strings.Trim(allowed, "{}[]()")
+	if !strings.ContainsAny(list, ",|") {
+		return nil
+	}
+	options := strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == '|' })
+	isOption := func(v string) bool {
+		return slices.ContainsFunc(options, func(o string) bool {
+			return strings.EqualFold(strings.TrimSpace(o), strings.TrimSpace(v))
+		})
+	}
+
+	if !slices.Contains(mysqlSetParameters, strings.ToLower(name)) {
+		if !isOption(value) {
+			return fmt.Errorf("value %q is not one of %s", value, allowed)
+		}
+		return nil
+	}
+	for _, v := range strings.Split(value, ",") {
+		// An empty set, such as sql_mode='', is valid
+		if strings.TrimSpace(v) != "" && !isOption(v) {
+			return fmt.Errorf("value %q contains %q, which is not one of %s", value, strings.TrimSpace(v), allowed)
+		}
+	}
+	return nil
+}
+
+func applyMySQLParameterGroup(ctx context.Context, cfg *config.Config, in ApplyMySQLParameterGroupInput) (ApplyMySQLParameterGroupOutput, error) {
+	if len(in.InstanceIDs) == 0 {
+		return ApplyMySQLParameterGroupOutput{}, fmt.Errorf("instance_ids is required")
+	}
+
+	group, err := fetchMySQLParameterGroup(ctx, cfg, in.ParameterGroupID)
+	if err != nil {
+		return ApplyMySQLParameterGroupOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return ApplyMySQLParameterGroupOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	rds := client.MySQL()
+
+	// Check every instance before changing any of them
+	instances := make([]*mysql.GetInstanceOutput, 0, len(in.InstanceIDs))
+	var problems validationErrors
+	for _, id := range in.InstanceIDs {
+		inst, err := rds.GetInstance(ctx, id)
+		if err != nil {
+			problems.addf("instance %s: %v", id, err)
+			continue
+		}
+		if inst.DBVersion != group.DBVersion {
+			problems.addf("instance %s (%s) runs %s, but group %s is for %s", inst.DBInstanceName, id, inst.DBVersion, group.ParameterGroupName, group.DBVersion)
+		}
+		if inst.ParameterGroupID != in.ParameterGroupID && inst.DBInstanceStatus != mysqlStatusAvailable {
+			problems.addf("instance %s (%s) is %s, expected %s", inst.DBInstanceName, id, inst.DBInstanceStatus, mysqlStatusAvailable)
+		}
+		instances = append(instances, inst)
+	}
+	if err := problems.err(); err != nil {
+		return ApplyMySQLParameterGroupOutput{}, err
+	}
+
+	// The SDK's ModifyInstance drops the job ID from the response
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return ApplyMySQLParameterGroupOutput{}, err
+	}
+
+	out := ApplyMySQLParameterGroupOutput{ParameterGroupID: in.ParameterGroupID}
+	for _, inst := range instances {
+		a := MySQLParameterGroupAssignment{
+			InstanceID: inst.DBInstanceID,
+			Name:       inst.DBInstanceName,
+			Previous:   inst.ParameterGroupID,
+		}
+		if inst.ParameterGroupID == in.ParameterGroupID {
+			a.Result = "already uses this group"
+			out.Instances = append(out.Instances, a)
+			continue
+		}
+
+		var job mysqlAPIJob
+		err := api.put(ctx, "/db-instances/"+url.PathEscape(inst.DBInstanceID), &mysql.ModifyInstanceInput{
+			ParameterGroupID:  in.ParameterGroupID,
+			UseOnlineFailover: in.UseOnlineFailover,
+		}, &job)
+		if err != nil {
+			a.Result = fmt.Sprintf("failed: %v", err)
+		} else {
+			a.Result = "assigned"
+			a.JobID = job.JobID
+		}
+		out.Instances = append(out.Instances, a)
+	}
+	return out, nil
+}
+
+func diffMySQLParameterGroups(ctx context.Context, cfg *config.Config, leftID, rightID string) (DiffMySQLParameterGroupsOutput, error) {
+	left, err := fetchMySQLParameterGroup(ctx, cfg, leftID)
+	if err != nil {
+		return DiffMySQLParameterGroupsOutput{}, err
+	}
+
+	out := DiffMySQLParameterGroupsOutput{Left: left.ParameterGroupName}
+
+	if rightID == "" {
+		out.Right = "engine defaults"
+		for _, p := range left.Parameters {
+			if p.Value != p.DefaultValue {
+				out.Differences = append(out.Differences, MySQLParameterDifference{Name: p.ParameterName, Left: p.Value, Right: p.DefaultValue})
+			}
+		}
+		return out, nil
+	}
+
+	right, err := fetchMySQLParameterGroup(ctx, cfg, rightID)
+	if err != nil {
+		return DiffMySQLParameterGroupsOutput{}, err
+	}
+	out.Right = right.ParameterGroupName
+
+	rightValues := make(map[string]string, len(right.Parameters))
+	for _, p := range right.Parameters {
+		rightValues[p.ParameterName] = p.Value
+	}
+	seen := make(map[string]bool, len(left.Parameters))
+	for _, p := range left.Parameters {
+		seen[p.ParameterName] = true
+		v, ok := rightValues[p.ParameterName]
+		switch {
+		case !ok:
+			out.OnlyInLeft = append(out.OnlyInLeft, p.ParameterName)
+		case v != p.Value:
+			out.Differences = append(out.Differences, MySQLParameterDifference{Name: p.ParameterName, Left: p.Value, Right: v})
+		}
+	}
+	for _, p := range right.Parameters {
+		if !seen[p.ParameterName] {
+			out.OnlyInRight = append(out.OnlyInRight, p.ParameterName)
+		}
+	}
+	return out, nil
+}
+
+func fetchMySQLParameterGroup(ctx context.Context, cfg *config.Config, parameterGroupID string) (*mysql.ParameterGroupOutput, error) {
+	if parameterGroupID == "" {
+		return nil, fmt.Errorf("parameter_group_id is required")
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	group, err := client.MySQL().GetParameterGroup(ctx, parameterGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parameter group: %w", err)
+	}
+	return group, nil
+}
+
+func newMySQLParameterGroup(g mysql.ParameterGroup) MySQLParameterGroup {
+	return MySQLParameterGroup{
+		ID:          g.ParameterGroupID,
+		Name:        g.ParameterGroupName,
+		Description: g.Description,
+		DBVersion:   g.DBVersion,
+		Status:      g.ParameterGroupStatus,
+		UpdatedAt:   g.UpdatedYmdt,
+	}
+}
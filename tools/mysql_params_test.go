@@ -0,0 +1,35 @@
+package tools
+
+import "testing"
+
+func TestCheckMySQLParameterValue(t *testing.T) {
+	const sqlModes = "ALLOW_INVALID_DATES,ANSI_QUOTES,NO_ENGINE_SUBSTITUTION,ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES"
+	tests := []struct {
+		name    string
+		allowed string
+		value   string
+		ok      bool
+	}{
+		{"max_connections", "1-100000", "500", true},
+		{"max_connections", "1-100000", "0", false},
+		{"max_connections", "1-100000", "many", false},
+		{"long_query_time", "0-31536000", "0.5", true},
+		{"autocommit", "ON,OFF", "on", true},
+		{"autocommit", "ON,OFF", "MAYBE", false},
+		{"binlog_format", "{ROW|STATEMENT|MIXED}", "ROW", true},
+		{"binlog_format", "{ROW|STATEMENT|MIXED}", "ROW,MIXED", false},
+		{"sql_mode", sqlModes, "STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", true},
+		{"sql_mode", sqlModes, "strict_trans_tables, only_full_group_by", true},
+		{"sql_mode", sqlModes, "", true},
+		{"sql_mode", sqlModes, "STRICT_TRANS_TABLES,NO_SUCH_MODE", false},
+		{"log_output", "TABLE,FILE,NONE", "TABLE,FILE", true},
+		{"character_set_server", "", "utf8mb4", true},
+		{"init_connect", "any string", "SET NAMES utf8mb4", true},
+	}
+	for _, tt := range tests {
+		err := checkMySQLParameterValue(tt.name, tt.allowed, tt.value)
+		if (err == nil) != tt.ok {
+			t.Errorf("checkMySQLParameterValue(%q, %q, %q) = %v, want ok %v", tt.name, tt.allowed, tt.value, err, tt.ok)
+		}
+	}
+}
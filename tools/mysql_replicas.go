@@ -0,0 +1,453 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/rds/mysql"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// mysqlLagWindow is how far back replication lag samples are read
+const mysqlLagWindow = 10 * time.Minute
+
+var (
+	mysqlPromoteAction = mysqlLifecycleAction{
+		name:    "promote",
+		allowed: []string{mysqlStatusAvailable, mysqlStatusReplicationStop},
+	}
+	mysqlRebuildAction = mysqlLifecycleAction{
+		name:    "rebuild",
+		allowed: []string{mysqlStatusAvailable, mysqlStatusReplicationStop, mysqlStatusFailToConnect},
+	}
+)
+
+type CreateMySQLReplicaInput struct {
+	InstanceID       string   `json:"instance_id" jsonschema_description:"The ID of the master instance to replicate"`
+	Name             string   `json:"name" jsonschema_description:"Name of the new read replica"`
+	Description      string   `json:"description,omitempty" jsonschema_description:"Replica description (optional)"`
+	AvailabilityZone string   `json:"availability_zone,omitempty" jsonschema_description:"Availability zone for the replica (default: the master's)"`
+	Flavor           string   `json:"flavor,omitempty" jsonschema_description:"Flavor name or ID (default: the master's)"`
+	StorageSizeGB    int      `json:"storage_size_gb,omitempty" jsonschema_description:"Storage size in GB, at least the master's (default: the master's)"`
+	ParameterGroupID string   `json:"parameter_group_id,omitempty" jsonschema_description:"Parameter group ID (default: the master's)"`
+	SecurityGroupIDs []string `json:"security_group_ids,omitempty" jsonschema_description:"DB security group IDs (default: the master's)"`
+}
+
+// CreateMySQLReplicaOutput - output for creating a read replica
+type CreateMySQLReplicaOutput struct {
+	MasterID         string `json:"master_id"`
+	MasterName       string `json:"master_name"`
+	Name             string `json:"name"`
+	AvailabilityZone string `json:"availability_zone"`
+	Flavor           string `json:"flavor"`
+	StorageSizeGB    int    `json:"storage_size_gb"`
+	JobID            string `json:"job_id"`
+}
+
+type ListMySQLReplicasInput struct {
+	InstanceID string `json:"instance_id,omitempty" jsonschema_description:"Only show the replication group of this instance (optional)"`
+	IncludeLag bool   `json:"include_lag,omitempty" jsonschema_description:"Also read the current replication lag of every replica; one metrics call per replica"`
+}
+
+// MySQLReplica is a read replica with its replication lag
+type MySQLReplica struct {
+	Instance   MySQLInstance `json:"instance"`
+	LagSeconds *float64      `json:"lag_seconds,omitempty"`
+}
+
+// MySQLReplicationGroup is a master with its HA candidate and read replicas
+type MySQLReplicationGroup struct {
+	GroupID   string          `json:"group_id"`
+	Master    *MySQLInstance  `json:"master,omitempty"`
+	Candidate *MySQLInstance  `json:"candidate,omitempty"`
+	Replicas  []MySQLReplica  `json:"replicas"`
+	Others    []MySQLInstance `json:"others,omitempty"`
+}
+
+// ListMySQLReplicasOutput - output for listing replicas per master
+type ListMySQLReplicasOutput struct {
+	Groups       []MySQLReplicationGroup `json:"groups"`
+	ReplicaCount int                     `json:"replica_count"`
+}
+
+type GetMySQLReplicationLagInput struct {
+	InstanceID string `json:"instance_id" jsonschema_description:"The ID of the read replica"`
+}
+
+// MySQLLagSample is one replication lag reading
+type MySQLLagSample struct {
+	Time       string  `json:"time"`
+	LagSeconds float64 `json:"lag_seconds"`
+}
+
+// GetMySQLReplicationLagOutput - output for reading replication lag
+type GetMySQLReplicationLagOutput struct {
+	InstanceID string           `json:"instance_id"`
+	Name       string           `json:"name"`
+	Status     string           `json:"status"`
+	Stopped    bool             `json:"replication_stopped"`
+	LagSeconds *float64         `json:"lag_seconds,omitempty"`
+	MaxSeconds *float64         `json:"max_lag_seconds,omitempty"`
+	Samples    []MySQLLagSample `json:"samples"`
+}
+
+type PromoteMySQLReplicaInput struct {
+	InstanceID  string `json:"instance_id" jsonschema_description:"The ID of the read replica"`
+	ConfirmName string `json:"confirm_name" jsonschema_description:"The replica name, repeated to confirm. Promotion is irreversible."`
+}
+
+type RebuildMySQLReplicaInput struct {
+	InstanceID  string `json:"instance_id" jsonschema_description:"The ID of the read replica"`
+	ConfirmName string `json:"confirm_name" jsonschema_description:"The replica name, repeated to confirm. The replica's data is discarded and copied again from the master."`
+}
+
+func registerMySQLReplicaTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_create_replica",
+		Description: "Create a read replica of an NHN Cloud RDS MySQL master instance, optionally in another availability zone or with another flavor. Settings not given are copied from the master.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[CreateMySQLReplicaInput]) (*mcp.CallToolResultFor[CreateMySQLReplicaOutput], error) {
+		out, err := createMySQLReplica(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[CreateMySQLReplicaOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[CreateMySQLReplicaOutput]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Creating read replica %s of %s in %s (%s, %d GB, job %s)",
+				out.Name, out.MasterName, out.AvailabilityZone, out.Flavor, out.StorageSizeGB, out.JobID)}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_list_replicas",
+		Description: "List NHN Cloud RDS MySQL replication groups: each master with its HA candidate and read replicas, optionally with the current replication lag of every replica.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLReplicasInput]) (*mcp.CallToolResultFor[ListMySQLReplicasOutput], error) {
+		out, err := listMySQLReplicas(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[ListMySQLReplicasOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[ListMySQLReplicasOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Found %d read replicas in %d replication groups", out.ReplicaCount, len(out.Groups))}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_get_replication_lag",
+		Description: "Show the replication lag of an NHN Cloud RDS MySQL read replica over the last 10 minutes.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[GetMySQLReplicationLagInput]) (*mcp.CallToolResultFor[GetMySQLReplicationLagOutput], error) {
+		out, err := getMySQLReplicationLag(ctx, cfg, params.Arguments.InstanceID)
+		if err != nil {
+			return &mcp.CallToolResultFor[GetMySQLReplicationLagOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		var text string
+		switch {
+		case out.Stopped:
+			text = fmt.Sprintf("Replication on %s is stopped (%s); rebuild it with nhn_mysql_rebuild_replica", out.Name, out.Status)
+		case out.LagSeconds == nil:
+			text = fmt.Sprintf("No replication lag samples for %s in the last %s", out.Name, mysqlLagWindow)
+		default:
+			text = fmt.Sprintf("Replica %s is %.0fs behind its master (max %.0fs in the last %s)", out.Name, *out.LagSeconds, *out.MaxSeconds, mysqlLagWindow)
+		}
+		return &mcp.CallToolResultFor[GetMySQLReplicationLagOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_promote_replica",
+		Description: "Promote an NHN Cloud RDS MySQL read replica to a standalone master. Replication from the old master stops for good. confirm_name must repeat the replica name.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[PromoteMySQLReplicaInput]) (*mcp.CallToolResultFor[MySQLInstanceActionOutput], error) {
+		out, err := promoteMySQLReplica(ctx, cfg, params.Arguments.InstanceID, params.Arguments.ConfirmName)
+		return mysqlInstanceActionResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_rebuild_replica",
+		Description: "Rebuild a broken NHN Cloud RDS MySQL read replica, e.g. one in REPLICATION_STOP. Its data is discarded and copied again from the master; it is unavailable meanwhile. confirm_name must repeat the replica name.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[RebuildMySQLReplicaInput]) (*mcp.CallToolResultFor[MySQLInstanceActionOutput], error) {
+		out, err := rebuildMySQLReplica(ctx, cfg, params.Arguments.InstanceID, params.Arguments.ConfirmName)
+		return mysqlInstanceActionResult(out, err)
+	})
+}
+
+func createMySQLReplica(ctx context.Context, cfg *config.Config, in CreateMySQLReplicaInput) (CreateMySQLReplicaOutput, error) {
+	if in.InstanceID == "" {
+		return CreateMySQLReplicaOutput{}, fmt.Errorf("instance_id is required")
+	}
+	if !mysqlInstanceNamePattern.MatchString(in.Name) {
+		return CreateMySQLReplicaOutput{}, fmt.Errorf("name %q must be 1-100 characters of letters, digits, '.', '_' or '-', starting with a letter", in.Name)
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return CreateMySQLReplicaOutput{}, err
+	}
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return CreateMySQLReplicaOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	rds := client.MySQL()
+
+	master, err := fetchMySQLInstanceRecord(ctx, api, in.InstanceID)
+	if err != nil {
+		return CreateMySQLReplicaOutput{}, err
+	}
+	if master.DBInstanceType != mysqlRoleMaster {
+		return CreateMySQLReplicaOutput{}, fmt.Errorf("instance %s is a %s; read replicas can only be created from a %s", master.DBInstanceName, master.DBInstanceType, mysqlRoleMaster)
+	}
+	if master.DBInstanceStatus != mysqlStatusAvailable {
+		return CreateMySQLReplicaOutput{}, fmt.Errorf("master %s is %s; it must be %s to be replicated", master.DBInstanceName, master.DBInstanceStatus, mysqlStatusAvailable)
+	}
+	if id, err := findMySQLInstanceIDByName(ctx, rds, in.Name); err != nil {
+		return CreateMySQLReplicaOutput{}, fmt.Errorf("failed to check name: %w", err)
+	} else if id != "" {
+		return CreateMySQLReplicaOutput{}, fmt.Errorf("an instance named %s already exists (%s)", in.Name, id)
+	}
+
+	flavorRef := in.Flavor
+	if flavorRef == "" {
+		flavorRef = master.DBFlavorID
+	}
+	flavor, err := resolveMySQLFlavor(ctx, rds, flavorRef)
+	if err != nil {
+		return CreateMySQLReplicaOutput{}, err
+	}
+
+	storage := in.StorageSizeGB
+	if storage == 0 {
+		storage = master.StorageSize
+	}
+	if storage < master.StorageSize || storage > mysqlMaxStorageGB {
+		return CreateMySQLReplicaOutput{}, fmt.Errorf("storage_size_gb %d must be between the master's %d and %d", storage, master.StorageSize, mysqlMaxStorageGB)
+	}
+
+	zone := in.AvailabilityZone
+	if zone == "" {
+		network, err := rds.GetNetworkInfo(ctx, in.InstanceID)
+		if err != nil {
+			return CreateMySQLReplicaOutput{}, fmt.Errorf("failed to read master availability zone: %w", err)
+		}
+		zone = network.AvailabilityZone
+	}
+
+	req := &mysql.CreateReplicaRequest{
+		DBInstanceName:     in.Name,
+		Description:        in.Description,
+		DBFlavorID:         flavor.FlavorID,
+		DBPort:             master.DBPort,
+		ParameterGroupID:   in.ParameterGroupID,
+		DBSecurityGroupIDs: in.SecurityGroupIDs,
+		Network:            &mysql.ReplicaNetwork{AvailabilityZone: zone},
+		Storage:            &mysql.ReplicaStorage{StorageType: master.StorageType, StorageSize: storage},
+	}
+	if req.ParameterGroupID == "" {
+		req.ParameterGroupID = master.ParameterGroupID
+	}
+	if req.DBSecurityGroupIDs == nil {
+		req.DBSecurityGroupIDs = master.DBSecurityGroupIDs
+	}
+
+	result, err := rds.CreateReplica(ctx, in.InstanceID, req)
+	if err != nil {
+		return CreateMySQLReplicaOutput{}, fmt.Errorf("failed to create replica: %w", err)
+	}
+	return CreateMySQLReplicaOutput{
+		MasterID:         in.InstanceID,
+		MasterName:       master.DBInstanceName,
+		Name:             in.Name,
+		AvailabilityZone: zone,
+		Flavor:           flavor.FlavorName,
+		StorageSizeGB:    storage,
+		JobID:            result.JobID,
+	}, nil
+}
+
+func listMySQLReplicas(ctx context.Context, cfg *config.Config, in ListMySQLReplicasInput) (ListMySQLReplicasOutput, error) {
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return ListMySQLReplicasOutput{}, err
+	}
+	records, err := fetchMySQLInstanceRecords(ctx, api)
+	if err != nil {
+		return ListMySQLReplicasOutput{}, err
+	}
+
+	groupID := ""
+	if in.InstanceID != "" {
+		idx := slices.IndexFunc(records, func(r mysqlInstanceRecord) bool { return r.DBInstanceID == in.InstanceID })
+		if idx < 0 {
+			return ListMySQLReplicasOutput{}, fmt.Errorf("instance %s not found", in.InstanceID)
+		}
+		groupID = records[idx].DBInstanceGroupID
+	}
+
+	var out ListMySQLReplicasOutput
+	index := make(map[string]int)
+	for _, rec := range records {
+		if groupID != "" && rec.DBInstanceGroupID != groupID {
+			continue
+		}
+		i, ok := index[rec.DBInstanceGroupID]
+		if !ok {
+			i = len(out.Groups)
+			index[rec.DBInstanceGroupID] = i
+			out.Groups = append(out.Groups, MySQLReplicationGroup{GroupID: rec.DBInstanceGroupID, Replicas: []MySQLReplica{}})
+		}
+		g := &out.Groups[i]
+
+		inst := newMySQLInstance(rec)
+		switch rec.DBInstanceType {
+		case mysqlRoleMaster:
+			g.Master = &inst
+		case mysqlRoleCandidateMaster:
+			g.Candidate = &inst
+		case mysqlRoleReplica:
+			replica := MySQLReplica{Instance: inst}
+			if in.IncludeLag {
+				lag, err := getMySQLReplicationLagWithAPI(ctx, api, rec)
+				if err != nil {
+					return ListMySQLReplicasOutput{}, err
+				}
+				replica.LagSeconds = lag.LagSeconds
+			}
+			g.Replicas = append(g.Replicas, replica)
+			out.ReplicaCount++
+		default:
+			g.Others = append(g.Others, inst)
+		}
+	}
+
+	// Standalone masters are noise when looking for replicas
+	if groupID == "" {
+		out.Groups = slices.DeleteFunc(out.Groups, func(g MySQLReplicationGroup) bool {
+			return len(g.Replicas) == 0 && g.Candidate == nil && len(g.Others) == 0
+		})
+	}
+	if out.Groups == nil {
+		out.Groups = []MySQLReplicationGroup{}
+	}
+	return out, nil
+}
+
+func getMySQLReplicationLag(ctx context.Context, cfg *config.Config, instanceID string) (GetMySQLReplicationLagOutput, error) {
+	if instanceID == "" {
+		return GetMySQLReplicationLagOutput{}, fmt.Errorf("instance_id is required")
+	}
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return GetMySQLReplicationLagOutput{}, err
+	}
+	rec, err := fetchMySQLInstanceRecord(ctx, api, instanceID)
+	if err != nil {
+		return GetMySQLReplicationLagOutput{}, err
+	}
+	if rec.DBInstanceType != mysqlRoleReplica {
+		return GetMySQLReplicationLagOutput{}, fmt.Errorf("instance %s is a %s, not a read replica", rec.DBInstanceName, rec.DBInstanceType)
+	}
+	return getMySQLReplicationLagWithAPI(ctx, api, rec)
+}
+
+func getMySQLReplicationLagWithAPI(ctx context.Context, api *mysqlAPI, rec mysqlInstanceRecord) (GetMySQLReplicationLagOutput, error) {
+	out := GetMySQLReplicationLagOutput{
+		InstanceID: rec.DBInstanceID,
+		Name:       rec.DBInstanceName,
+		Status:     rec.DBInstanceStatus,
+		Stopped:    rec.DBInstanceStatus == mysqlStatusReplicationStop,
+		Samples:    []MySQLLagSample{},
+	}
+
+	measure, err := findMySQLMeasure(ctx, api, "REPLICATION", "DELAY")
+	if err != nil {
+		return GetMySQLReplicationLagOutput{}, err
+	}
+	to := time.Now()
+	series, err := fetchMySQLMetricSeries(ctx, api, rec.DBInstanceID, []string{measure}, to.Add(-mysqlLagWindow), to, 1)
+	if err != nil {
+		return GetMySQLReplicationLagOutput{}, err
+	}
+	if len(series) == 0 {
+		return out, nil
+	}
+
+	for _, p := range series[0].Points {
+		out.Samples = append(out.Samples, MySQLLagSample{Time: p.Time, LagSeconds: p.Value})
+		if out.MaxSeconds == nil || p.Value > *out.MaxSeconds {
+			peak := p.Value
+			out.MaxSeconds = &peak
+		}
+	}
+	if n := len(out.Samples); n > 0 {
+		last := out.Samples[n-1].LagSeconds
+		out.LagSeconds = &last
+	}
+	return out, nil
+}
+
+func promoteMySQLReplica(ctx context.Context, cfg *config.Config, instanceID, confirmName string) (MySQLInstanceActionOutput, error) {
+	inst, err := checkMySQLReplicaAction(ctx, cfg, instanceID, confirmName, mysqlPromoteAction)
+	if err != nil {
+		return MySQLInstanceActionOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLInstanceActionOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	result, err := client.MySQL().PromoteReplica(ctx, instanceID)
+	if err != nil {
+		return MySQLInstanceActionOutput{}, fmt.Errorf("failed to promote replica: %w", err)
+	}
+	return newMySQLInstanceActionOutput(inst, "promote", result.JobID), nil
+}
+
+func rebuildMySQLReplica(ctx context.Context, cfg *config.Config, instanceID, confirmName string) (MySQLInstanceActionOutput, error) {
+	inst, err := checkMySQLReplicaAction(ctx, cfg, instanceID, confirmName, mysqlRebuildAction)
+	if err != nil {
+		return MySQLInstanceActionOutput{}, err
+	}
+
+	// The SDK has no rebuild call
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return MySQLInstanceActionOutput{}, err
+	}
+	var job mysqlAPIJob
+	if err := api.post(ctx, "/db-instances/"+url.PathEscape(instanceID)+"/rebuild", nil, &job); err != nil {
+		return MySQLInstanceActionOutput{}, fmt.Errorf("failed to rebuild replica: %w", err)
+	}
+	return newMySQLInstanceActionOutput(inst, "rebuild", job.JobID), nil
+}
+
+// checkMySQLReplicaAction loads a read replica and checks the confirmation,
+// its role and its status before a replica-only action
+func checkMySQLReplicaAction(ctx context.Context, cfg *config.Config, instanceID, confirmName string, action mysqlLifecycleAction) (MySQLInstance, error) {
+	inst, err := getMySQLInstance(ctx, cfg, instanceID)
+	if err != nil {
+		return MySQLInstance{}, err
+	}
+	if err := checkMySQLConfirmName(action.name, "instance "+instanceID, confirmName, inst.Instance.Name); err != nil {
+		return MySQLInstance{}, err
+	}
+	if inst.Instance.Role != mysqlRoleReplica {
+		return MySQLInstance{}, fmt.Errorf("cannot %s instance %s: it is a %s, not a read replica", action.name, inst.Instance.Name, inst.Instance.Role)
+	}
+	if err := action.check(inst.Instance); err != nil {
+		return MySQLInstance{}, err
+	}
+	return inst.Instance, nil
+}
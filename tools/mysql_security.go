@@ -0,0 +1,639 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/rds/mysql"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DB security group rule values
+const (
+	mysqlRuleIngress   = "INGRESS"
+	mysqlRuleEgress    = "EGRESS"
+	mysqlRuleIPv4      = "IPV4"
+	mysqlRuleIPv6      = "IPV6"
+	mysqlRulePort      = "PORT"
+	mysqlRulePortRange = "PORT_RANGE"
+	mysqlRuleDBPort    = "DB_PORT"
+)
+
+// mysqlSecurityGroupResponse accepts the group both flat and nested under
+// dbSecurityGroup; the SDK only decodes the nested form
+type mysqlSecurityGroupResponse struct {
+	mysql.DBSecurityGroup
+	Nested *mysql.DBSecurityGroup `json:"dbSecurityGroup"`
+}
+
+func (r mysqlSecurityGroupResponse) group() mysql.DBSecurityGroup {
+	if r.Nested != nil && r.Nested.DBSecurityGroupID != "" {
+		return *r.Nested
+	}
+	return r.DBSecurityGroup
+}
+
+// MySQLSecurityRuleInput describes a DB security group rule
+type MySQLSecurityRuleInput struct {
+	Description string `json:"description,omitempty" jsonschema_description:"Rule description (optional)"`
+	Direction   string `json:"direction,omitempty" jsonschema_description:"INGRESS or EGRESS (default INGRESS)"`
+	CIDR        string `json:"cidr" jsonschema_description:"Allowed network in CIDR notation, e.g. 10.0.0.0/24"`
+	PortType    string `json:"port_type,omitempty" jsonschema_description:"DB_PORT (the instance's port), PORT or PORT_RANGE (default DB_PORT)"`
+	MinPort     int    `json:"min_port,omitempty" jsonschema_description:"Port for PORT, or first port for PORT_RANGE"`
+	MaxPort     int    `json:"max_port,omitempty" jsonschema_description:"Last port for PORT_RANGE"`
+}
+
+// MySQLSecurityRule represents a DB security group rule
+type MySQLSecurityRule struct {
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	Direction   string `json:"direction"`
+	EtherType   string `json:"ether_type"`
+	CIDR        string `json:"cidr"`
+	PortType    string `json:"port_type"`
+	MinPort     int    `json:"min_port,omitempty"`
+	MaxPort     int    `json:"max_port,omitempty"`
+	OpenToWorld bool   `json:"open_to_world"`
+}
+
+// MySQLInstanceRef identifies an instance
+type MySQLInstanceRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// MySQLSecurityGroup represents a DB security group
+type MySQLSecurityGroup struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Status      string              `json:"status"`
+	Rules       []MySQLSecurityRule `json:"rules"`
+	Instances   []MySQLInstanceRef  `json:"instances"`
+	OpenToWorld bool                `json:"open_to_world"`
+}
+
+type ListMySQLSecurityGroupsInput struct{}
+
+// ListMySQLSecurityGroupsOutput - output for listing DB security groups
+type ListMySQLSecurityGroupsOutput struct {
+	SecurityGroups []MySQLSecurityGroup `json:"security_groups"`
+	Count          int                  `json:"count"`
+}
+
+type GetMySQLSecurityGroupInput struct {
+	SecurityGroupID string `json:"security_group_id" jsonschema_description:"The ID of the DB security group"`
+}
+
+type CreateMySQLSecurityGroupInput struct {
+	Name        string                   `json:"name" jsonschema_description:"Security group name"`
+	Description string                   `json:"description,omitempty" jsonschema_description:"Security group description (optional)"`
+	Rules       []MySQLSecurityRuleInput `json:"rules" jsonschema_description:"At least one rule"`
+}
+
+type UpdateMySQLSecurityGroupInput struct {
+	SecurityGroupID string `json:"security_group_id" jsonschema_description:"The ID of the DB security group"`
+	Name            string `json:"name,omitempty" jsonschema_description:"New name (optional)"`
+	Description     string `json:"description,omitempty" jsonschema_description:"New description (optional)"`
+}
+
+type DeleteMySQLSecurityGroupInput struct {
+	SecurityGroupID string `json:"security_group_id" jsonschema_description:"The ID of the DB security group"`
+	ConfirmName     string `json:"confirm_name" jsonschema_description:"The security group name, repeated to confirm deletion"`
+}
+
+type CreateMySQLSecurityRuleInput struct {
+	SecurityGroupID string                 `json:"security_group_id" jsonschema_description:"The ID of the DB security group"`
+	Rule            MySQLSecurityRuleInput `json:"rule" jsonschema_description:"The rule to add"`
+}
+
+type UpdateMySQLSecurityRuleInput struct {
+	SecurityGroupID string                 `json:"security_group_id" jsonschema_description:"The ID of the DB security group"`
+	RuleID          string                 `json:"rule_id" jsonschema_description:"The ID of the rule"`
+	Rule            MySQLSecurityRuleInput `json:"rule" jsonschema_description:"The replacement rule"`
+}
+
+type DeleteMySQLSecurityRuleInput struct {
+	SecurityGroupID string `json:"security_group_id" jsonschema_description:"The ID of the DB security group"`
+	RuleID          string `json:"rule_id" jsonschema_description:"The ID of the rule"`
+}
+
+// MySQLSecurityGroupChangeOutput - output for security group and rule changes
+type MySQLSecurityGroupChangeOutput struct {
+	SecurityGroupID string   `json:"security_group_id"`
+	RuleID          string   `json:"rule_id,omitempty"`
+	Action          string   `json:"action"`
+	Warnings        []string `json:"warnings,omitempty"`
+}
+
+func registerMySQLSecurityGroupTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_list_security_groups",
+		Description: "List NHN Cloud RDS MySQL DB security groups with their rules and the instances each is attached to. Groups with an ingress rule open to 0.0.0.0/0 or ::/0 are flagged.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLSecurityGroupsInput]) (*mcp.CallToolResultFor[ListMySQLSecurityGroupsOutput], error) {
+		out, err := listMySQLSecurityGroups(ctx, cfg)
+		if err != nil {
+			return &mcp.CallToolResultFor[ListMySQLSecurityGroupsOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		text := fmt.Sprintf("Found %d DB security groups", out.Count)
+		for _, g := range out.SecurityGroups {
+			if g.OpenToWorld {
+				text += fmt.Sprintf("\nWarning: %s (%s) has an ingress rule open to the whole internet", g.Name, g.ID)
+			}
+		}
+		return &mcp.CallToolResultFor[ListMySQLSecurityGroupsOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_get_security_group",
+		Description: "Get an NHN Cloud RDS MySQL DB security group with its rules and attached instances.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[GetMySQLSecurityGroupInput]) (*mcp.CallToolResultFor[MySQLSecurityGroup], error) {
+		out, err := getMySQLSecurityGroup(ctx, cfg, params.Arguments.SecurityGroupID)
+		if err != nil {
+			return &mcp.CallToolResultFor[MySQLSecurityGroup]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		text := fmt.Sprintf("DB security group %s: %d rules, attached to %d instances", out.Name, len(out.Rules), len(out.Instances))
+		if out.OpenToWorld {
+			text += "\nWarning: an ingress rule is open to the whole internet"
+		}
+		return &mcp.CallToolResultFor[MySQLSecurityGroup]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_create_security_group",
+		Description: "Create an NHN Cloud RDS MySQL DB security group with rules. CIDRs are validated; ingress rules open to 0.0.0.0/0 or ::/0 are allowed but flagged.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[CreateMySQLSecurityGroupInput]) (*mcp.CallToolResultFor[MySQLSecurityGroupChangeOutput], error) {
+		out, err := createMySQLSecurityGroup(ctx, cfg, params.Arguments)
+		return mysqlSecurityGroupChangeResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_update_security_group",
+		Description: "Rename an NHN Cloud RDS MySQL DB security group or change its description.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[UpdateMySQLSecurityGroupInput]) (*mcp.CallToolResultFor[MySQLSecurityGroupChangeOutput], error) {
+		out, err := updateMySQLSecurityGroup(ctx, cfg, params.Arguments)
+		return mysqlSecurityGroupChangeResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_delete_security_group",
+		Description: "Delete an NHN Cloud RDS MySQL DB security group. confirm_name must repeat the group name, and the group must not be attached to any instance.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[DeleteMySQLSecurityGroupInput]) (*mcp.CallToolResultFor[MySQLSecurityGroupChangeOutput], error) {
+		out, err := deleteMySQLSecurityGroup(ctx, cfg, params.Arguments.SecurityGroupID, params.Arguments.ConfirmName)
+		return mysqlSecurityGroupChangeResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_create_security_group_rule",
+		Description: "Add a rule to an NHN Cloud RDS MySQL DB security group. The change applies to every attached instance.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[CreateMySQLSecurityRuleInput]) (*mcp.CallToolResultFor[MySQLSecurityGroupChangeOutput], error) {
+		out, err := createMySQLSecurityRule(ctx, cfg, params.Arguments.SecurityGroupID, params.Arguments.Rule)
+		return mysqlSecurityGroupChangeResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_update_security_group_rule",
+		Description: "Replace a rule of an NHN Cloud RDS MySQL DB security group. The change applies to every attached instance.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[UpdateMySQLSecurityRuleInput]) (*mcp.CallToolResultFor[MySQLSecurityGroupChangeOutput], error) {
+		out, err := updateMySQLSecurityRule(ctx, cfg, params.Arguments)
+		return mysqlSecurityGroupChangeResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_delete_security_group_rule",
+		Description: "Remove a rule from an NHN Cloud RDS MySQL DB security group. The change applies to every attached instance.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[DeleteMySQLSecurityRuleInput]) (*mcp.CallToolResultFor[MySQLSecurityGroupChangeOutput], error) {
+		out, err := deleteMySQLSecurityRule(ctx, cfg, params.Arguments.SecurityGroupID, params.Arguments.RuleID)
+		return mysqlSecurityGroupChangeResult(out, err)
+	})
+}
+
+func mysqlSecurityGroupChangeResult(out MySQLSecurityGroupChangeOutput, err error) (*mcp.CallToolResultFor[MySQLSecurityGroupChangeOutput], error) {
+	if err != nil {
+		return &mcp.CallToolResultFor[MySQLSecurityGroupChangeOutput]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+			IsError: true,
+		}, nil
+	}
+
+	text := fmt.Sprintf("DB security group %s: %s", out.SecurityGroupID, out.Action)
+	for _, w := range out.Warnings {
+		text += "\nWarning: " + w
+	}
+	return &mcp.CallToolResultFor[MySQLSecurityGroupChangeOutput]{
+		Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+		StructuredContent: out,
+	}, nil
+}
+
+func listMySQLSecurityGroups(ctx context.Context, cfg *config.Config) (ListMySQLSecurityGroupsOutput, error) {
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return ListMySQLSecurityGroupsOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return ListMySQLSecurityGroupsOutput{}, err
+	}
+
+	result, err := client.MySQL().ListSecurityGroups(ctx)
+	if err != nil {
+		return ListMySQLSecurityGroupsOutput{}, fmt.Errorf("failed to list DB security groups: %w", err)
+	}
+	attached, err := mysqlSecurityGroupAttachments(ctx, client.MySQL())
+	if err != nil {
+		return ListMySQLSecurityGroupsOutput{}, err
+	}
+
+	groups := make([]MySQLSecurityGroup, 0, len(result.DBSecurityGroups))
+	for _, g := range result.DBSecurityGroups {
+		// The list omits rules, so fetch each group
+		detail, err := fetchMySQLSecurityGroup(ctx, api, g.DBSecurityGroupID)
+		if err != nil {
+			return ListMySQLSecurityGroupsOutput{}, err
+		}
+		groups = append(groups, newMySQLSecurityGroup(detail, attached[g.DBSecurityGroupID]))
+	}
+
+	return ListMySQLSecurityGroupsOutput{
+		SecurityGroups: groups,
+		Count:          len(groups),
+	}, nil
+}
+
+func getMySQLSecurityGroup(ctx context.Context, cfg *config.Config, securityGroupID string) (MySQLSecurityGroup, error) {
+	if securityGroupID == "" {
+		return MySQLSecurityGroup{}, fmt.Errorf("security_group_id is required")
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLSecurityGroup{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return MySQLSecurityGroup{}, err
+	}
+
+	detail, err := fetchMySQLSecurityGroup(ctx, api, securityGroupID)
+	if err != nil {
+		return MySQLSecurityGroup{}, err
+	}
+	attached, err := mysqlSecurityGroupAttachments(ctx, client.MySQL())
+	if err != nil {
+		return MySQLSecurityGroup{}, err
+	}
+	return newMySQLSecurityGroup(detail, attached[securityGroupID]), nil
+}
+
+func createMySQLSecurityGroup(ctx context.Context, cfg *config.Config, in CreateMySQLSecurityGroupInput) (MySQLSecurityGroupChangeOutput, error) {
+	var problems validationErrors
+	if in.Name == "" {
+		problems.addf("name is required")
+	}
+	if len(in.Rules) == 0 {
+		problems.addf("at least one rule is required")
+	}
+
+	req := &mysql.CreateSecurityGroupInput{DBSecurityGroupName: in.Name, Description: in.Description}
+	var warnings []string
+	for i, r := range in.Rules {
+		rule, warning, err := buildMySQLSecurityRule(r)
+		if err != nil {
+			problems.addf("rule %d: %v", i+1, err)
+			continue
+		}
+		if warning != "" {
+			warnings = append(warnings, fmt.Sprintf("rule %d: %s", i+1, warning))
+		}
+		req.Rules = append(req.Rules, struct {
+			Description string     `json:"description,omitempty"`
+			Direction   string     `json:"direction"`
+			EtherType   string     `json:"etherType"`
+			Port        mysql.Port `json:"port"`
+			CIDR        string     `json:"cidr"`
+		}(rule))
+	}
+	if err := problems.err(); err != nil {
+		return MySQLSecurityGroupChangeOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	result, err := client.MySQL().CreateSecurityGroup(ctx, req)
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("failed to create DB security group: %w", err)
+	}
+	return MySQLSecurityGroupChangeOutput{
+		SecurityGroupID: result.DBSecurityGroupID,
+		Action:          fmt.Sprintf("created %s with %d rules", in.Name, len(req.Rules)),
+		Warnings:        warnings,
+	}, nil
+}
+
+func updateMySQLSecurityGroup(ctx context.Context, cfg *config.Config, in UpdateMySQLSecurityGroupInput) (MySQLSecurityGroupChangeOutput, error) {
+	if in.SecurityGroupID == "" {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("security_group_id is required")
+	}
+	if in.Name == "" && in.Description == "" {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("set name or description")
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	_, err = client.MySQL().UpdateSecurityGroup(ctx, in.SecurityGroupID, &mysql.UpdateSecurityGroupInput{
+		DBSecurityGroupName: in.Name,
+		Description:         in.Description,
+	})
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("failed to update DB security group: %w", err)
+	}
+	return MySQLSecurityGroupChangeOutput{SecurityGroupID: in.SecurityGroupID, Action: "updated"}, nil
+}
+
+func deleteMySQLSecurityGroup(ctx context.Context, cfg *config.Config, securityGroupID, confirmName string) (MySQLSecurityGroupChangeOutput, error) {
+	if securityGroupID == "" {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("security_group_id is required")
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, err
+	}
+	group, err := fetchMySQLSecurityGroup(ctx, api, securityGroupID)
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, err
+	}
+	if err := checkMySQLConfirmName("deletion", "DB security group "+securityGroupID, confirmName, group.DBSecurityGroupName); err != nil {
+		return MySQLSecurityGroupChangeOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	attached, err := mysqlSecurityGroupAttachments(ctx, client.MySQL())
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, err
+	}
+	if refs := attached[securityGroupID]; len(refs) > 0 {
+		names := make([]string, 0, len(refs))
+		for _, r := range refs {
+			names = append(names, fmt.Sprintf("%s (%s)", r.Name, r.ID))
+		}
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("DB security group %s is attached to %s; detach it with nhn_mysql_modify_instance first", securityGroupID, strings.Join(names, ", "))
+	}
+
+	if _, err := client.MySQL().DeleteSecurityGroup(ctx, securityGroupID); err != nil {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("failed to delete DB security group: %w", err)
+	}
+	return MySQLSecurityGroupChangeOutput{SecurityGroupID: securityGroupID, Action: "deleted"}, nil
+}
+
+func createMySQLSecurityRule(ctx context.Context, cfg *config.Config, securityGroupID string, in MySQLSecurityRuleInput) (MySQLSecurityGroupChangeOutput, error) {
+	if securityGroupID == "" {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("security_group_id is required")
+	}
+	rule, warning, err := buildMySQLSecurityRule(in)
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	result, err := client.MySQL().CreateSecurityGroupRule(ctx, securityGroupID, &rule)
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("failed to create rule: %w", err)
+	}
+
+	out := MySQLSecurityGroupChangeOutput{
+		SecurityGroupID: securityGroupID,
+		RuleID:          result.RuleID,
+		Action:          fmt.Sprintf("added %s rule for %s", rule.Direction, rule.CIDR),
+	}
+	if warning != "" {
+		out.Warnings = append(out.Warnings, warning)
+	}
+	return out, nil
+}
+
+func updateMySQLSecurityRule(ctx context.Context, cfg *config.Config, in UpdateMySQLSecurityRuleInput) (MySQLSecurityGroupChangeOutput, error) {
+	if in.SecurityGroupID == "" || in.RuleID == "" {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("security_group_id and rule_id are required")
+	}
+	rule, warning, err := buildMySQLSecurityRule(in.Rule)
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	_, err = client.MySQL().UpdateSecurityGroupRule(ctx, in.SecurityGroupID, in.RuleID, &mysql.UpdateSecurityGroupRuleInput{
+		Description: rule.Description,
+		Direction:   rule.Direction,
+		EtherType:   rule.EtherType,
+		Port:        &rule.Port,
+		CIDR:        rule.CIDR,
+	})
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("failed to update rule: %w", err)
+	}
+
+	out := MySQLSecurityGroupChangeOutput{
+		SecurityGroupID: in.SecurityGroupID,
+		RuleID:          in.RuleID,
+		Action:          fmt.Sprintf("replaced rule with %s rule for %s", rule.Direction, rule.CIDR),
+	}
+	if warning != "" {
+		out.Warnings = append(out.Warnings, warning)
+	}
+	return out, nil
+}
+
+func deleteMySQLSecurityRule(ctx context.Context, cfg *config.Config, securityGroupID, ruleID string) (MySQLSecurityGroupChangeOutput, error) {
+	if securityGroupID == "" || ruleID == "" {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("security_group_id and rule_id are required")
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	if _, err := client.MySQL().DeleteSecurityGroupRule(ctx, securityGroupID, ruleID); err != nil {
+		return MySQLSecurityGroupChangeOutput{}, fmt.Errorf("failed to delete rule: %w", err)
+	}
+	return MySQLSecurityGroupChangeOutput{SecurityGroupID: securityGroupID, RuleID: ruleID, Action: "deleted rule"}, nil
+}
+
+// buildMySQLSecurityRule validates a rule and fills in defaults. The returned
+// warning is set for ingress rules open to the whole internet.
+func buildMySQLSecurityRule(in MySQLSecurityRuleInput) (mysql.CreateSecurityGroupRuleInput, string, error) {
+	var problems validationErrors
+
+	direction := strings.ToUpper(in.Direction)
+	if direction == "" {
+		direction = mysqlRuleIngress
+	}
+	if direction != mysqlRuleIngress && direction != mysqlRuleEgress {
+		problems.addf("direction %q must be %s or %s", in.Direction, mysqlRuleIngress, mysqlRuleEgress)
+	}
+
+	etherType := ""
+	ip, network, err := net.ParseCIDR(strings.TrimSpace(in.CIDR))
+	switch {
+	case err != nil:
+		problems.addf("cidr %q is not valid CIDR notation, e.g. 10.0.0.0/24", in.CIDR)
+	case !ip.Equal(network.IP):
+		problems.addf("cidr %q has host bits set; did you mean %s?", in.CIDR, network.String())
+	case ip.To4() != nil:
+		etherType = mysqlRuleIPv4
+	default:
+		etherType = mysqlRuleIPv6
+	}
+
+	portType := strings.ToUpper(in.PortType)
+	if portType == "" {
+		portType = mysqlRuleDBPort
+	}
+	port := mysql.Port{PortType: portType}
+	switch portType {
+	case mysqlRuleDBPort:
+		if in.MinPort != 0 || in.MaxPort != 0 {
+			problems.addf("min_port and max_port must not be set for port_type %s", mysqlRuleDBPort)
+		}
+	case mysqlRulePort:
+		if in.MinPort < 1 || in.MinPort > 65535 {
+			problems.addf("min_port %d must be between 1 and 65535", in.MinPort)
+		}
+		if in.MaxPort != 0 && in.MaxPort != in.MinPort {
+			problems.addf("max_port must be unset or equal to min_port for port_type %s", mysqlRulePort)
+		}
+		minPort, maxPort := in.MinPort, in.MinPort
+		port.MinPort, port.MaxPort = &minPort, &maxPort
+	case mysqlRulePortRange:
+		if in.MinPort < 1 || in.MaxPort > 65535 || in.MinPort > in.MaxPort {
+			problems.addf("port range %d-%d must satisfy 1 <= min_port <= max_port <= 65535", in.MinPort, in.MaxPort)
+		}
+		minPort, maxPort := in.MinPort, in.MaxPort
+		port.MinPort, port.MaxPort = &minPort, &maxPort
+	default:
+		problems.addf("port_type %q must be %s, %s or %s", in.PortType, mysqlRuleDBPort, mysqlRulePort, mysqlRulePortRange)
+	}
+
+	if err := problems.err(); err != nil {
+		return mysql.CreateSecurityGroupRuleInput{}, "", err
+	}
+
+	var warning string
+	if isOpenToWorld(direction, network.String()) {
+		warning = fmt.Sprintf("%s allows inbound traffic from any address on the internet", network.String())
+	}
+	return mysql.CreateSecurityGroupRuleInput{
+		Description: in.Description,
+		Direction:   direction,
+		EtherType:   etherType,
+		Port:        port,
+		CIDR:        network.String(),
+	}, warning, nil
+}
+
+// isOpenToWorld reports whether a rule admits connections from every
+// address. Egress to any address is the normal setting and does not count.
+func isOpenToWorld(direction, cidr string) bool {
+	return direction == mysqlRuleIngress && (cidr == "0.0.0.0/0" || cidr == "::/0")
+}
+
+func fetchMySQLSecurityGroup(ctx context.Context, api *mysqlAPI, securityGroupID string) (mysql.DBSecurityGroup, error) {
+	var resp mysqlSecurityGroupResponse
+	if err := api.get(ctx, "/db-security-groups/"+url.PathEscape(securityGroupID), nil, &resp); err != nil {
+		return mysql.DBSecurityGroup{}, fmt.Errorf("failed to get DB security group %s: %w", securityGroupID, err)
+	}
+	return resp.group(), nil
+}
+
+// mysqlSecurityGroupAttachments maps security group IDs to the instances using them.
+// The instance list omits security groups, so every instance is fetched.
+func mysqlSecurityGroupAttachments(ctx context.Context, rds *mysql.Client) (map[string][]MySQLInstanceRef, error) {
+	instances, err := rds.ListInstances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instances: %w", err)
+	}
+
+	attached := make(map[string][]MySQLInstanceRef)
+	for _, inst := range instances.DBInstances {
+		ids := inst.DBSecurityGroupIDs
+		if len(ids) == 0 {
+			detail, err := rds.GetInstance(ctx, inst.DBInstanceID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get instance %s: %w", inst.DBInstanceID, err)
+			}
+			ids = detail.DBSecurityGroupIDs
+		}
+		for _, id := range ids {
+			attached[id] = append(attached[id], MySQLInstanceRef{ID: inst.DBInstanceID, Name: inst.DBInstanceName})
+		}
+	}
+	return attached, nil
+}
+
+func newMySQLSecurityGroup(g mysql.DBSecurityGroup, instances []MySQLInstanceRef) MySQLSecurityGroup {
+	out := MySQLSecurityGroup{
+		ID:          g.DBSecurityGroupID,
+		Name:        g.DBSecurityGroupName,
+		Description: g.Description,
+		Status:      g.ProgressStatus,
+		Rules:       make([]MySQLSecurityRule, 0, len(g.Rules)),
+		Instances:   instances,
+	}
+	if out.Instances == nil {
+		out.Instances = []MySQLInstanceRef{}
+	}
+	for _, r := range g.Rules {
+		rule := MySQLSecurityRule{
+			ID:          r.RuleID,
+			Description: r.Description,
+			Direction:   r.Direction,
+			EtherType:   r.EtherType,
+			CIDR:        r.CIDR,
+			PortType:    r.Port.PortType,
+			OpenToWorld: isOpenToWorld(r.Direction, r.CIDR),
+		}
+		if r.Port.MinPort != nil {
+			rule.MinPort = *r.Port.MinPort
+		}
+		if r.Port.MaxPort != nil {
+			rule.MaxPort = *r.Port.MaxPort
+		}
+		out.OpenToWorld = out.OpenToWorld || rule.OpenToWorld
+		out.Rules = append(out.Rules, rule)
+	}
+	slices.SortFunc(out.Rules, func(a, b MySQLSecurityRule) int { return strings.Compare(a.CIDR, b.CIDR) })
+	return out
+}
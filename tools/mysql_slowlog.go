@@ -0,0 +1,496 @@
+package tools
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	mysqlSlowDefaultLimit    = 10
+	mysqlSlowMaxLimit        = 100
+	mysqlSlowMaxExample      = 4096
+	mysqlSlowTextFingerprint = 200
+)
+
+// mysqlSlowSortKeys maps sort_by values to the value queries are ranked by
+var mysqlSlowSortKeys = map[string]func(*mysqlSlowStats) float64{
+	"total_time":    func(s *mysqlSlowStats) float64 { return s.totalTime },
+	"count":         func(s *mysqlSlowStats) float64 { return float64(s.count) },
+	"rows_examined": func(s *mysqlSlowStats) float64 { return float64(s.rowsExamined) },
+	"lock_time":     func(s *mysqlSlowStats) float64 { return s.lockTime },
+}
+
+type AnalyzeMySQLSlowLogInput struct {
+	LocalPath      string `json:"local_path,omitempty" jsonschema_description:"Analyze a slow query log already on this machine, e.g. one saved by nhn_mysql_get_log_file"`
+	InstanceID     string `json:"instance_id,omitempty" jsonschema_description:"Analyze the slow query log of this MySQL instance instead of a local file"`
+	LogFileName    string `json:"log_file_name,omitempty" jsonschema_description:"Log file on the instance (default: the most recently updated slow query log)"`
+	Container      string `json:"container,omitempty" jsonschema_description:"Object Storage container the log is exported through (required with instance_id)"`
+	ObjectPath     string `json:"object_path,omitempty" jsonschema_description:"Path in the container to export to (default rds-logs/<instance_id>/<timestamp>)"`
+	TimeoutMinutes int    `json:"timeout_minutes,omitempty" jsonschema_description:"How long to wait for the export job (default 15, max 60)"`
+	SortBy         string `json:"sort_by,omitempty" jsonschema_description:"Rank queries by total_time (default), count, rows_examined or lock_time"`
+	Limit          int    `json:"limit,omitempty" jsonschema_description:"Number of top queries to return (default 10, max 100)"`
+}
+
+// MySQLSlowQuery is one normalized query in a slow log digest
+type MySQLSlowQuery struct {
+	Rank            int      `json:"rank"`
+	ID              string   `json:"id"`
+	Fingerprint     string   `json:"fingerprint"`
+	Count           int      `json:"count"`
+	TotalTime       float64  `json:"total_time_seconds"`
+	TimePercent     float64  `json:"time_percent"`
+	AvgTime         float64  `json:"avg_time_seconds"`
+	MaxTime         float64  `json:"max_time_seconds"`
+	LockTime        float64  `json:"lock_time_seconds"`
+	RowsSent        int64    `json:"rows_sent"`
+	RowsExamined    int64    `json:"rows_examined"`
+	AvgRowsExamined float64  `json:"avg_rows_examined"`
+	Databases       []string `json:"databases,omitempty"`
+	Users           []string `json:"users,omitempty"`
+	FirstSeen       string   `json:"first_seen,omitempty"`
+	LastSeen        string   `json:"last_seen,omitempty"`
+	Example         string   `json:"example"`
+}
+
+// AnalyzeMySQLSlowLogOutput - output for analyzing a slow query log
+type AnalyzeMySQLSlowLogOutput struct {
+	Source        string           `json:"source"`
+	Events        int              `json:"events"`
+	UniqueQueries int              `json:"unique_queries"`
+	TotalTime     float64          `json:"total_time_seconds"`
+	TotalLockTime float64          `json:"total_lock_time_seconds"`
+	From          string           `json:"from,omitempty"`
+	To            string           `json:"to,omitempty"`
+	SortBy        string           `json:"sort_by"`
+	Queries       []MySQLSlowQuery `json:"queries"`
+	Truncated     bool             `json:"truncated"`
+}
+
+func registerMySQLSlowLogTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_analyze_slow_log",
+		Description: "Digest a MySQL slow query log like pt-query-digest: statements are normalized into fingerprints (literals replaced by ?, IN lists collapsed) and the top fingerprints are reported by total time, count, rows examined or lock time. Reads a local file, or exports the slow log of an NHN Cloud RDS MySQL instance through Object Storage.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[AnalyzeMySQLSlowLogInput]) (*mcp.CallToolResultFor[AnalyzeMySQLSlowLogOutput], error) {
+		out, err := analyzeMySQLSlowLog(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[AnalyzeMySQLSlowLogOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[AnalyzeMySQLSlowLogOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: formatMySQLSlowLog(out)}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func formatMySQLSlowLog(out AnalyzeMySQLSlowLogOutput) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d queries, %d unique, %.3fs total", out.Source, out.Events, out.UniqueQueries, out.TotalTime)
+	if out.From != "" {
+		fmt.Fprintf(&b, " between %s and %s", out.From, out.To)
+	}
+	fmt.Fprintf(&b, "\nTop %d by %s:", len(out.Queries), out.SortBy)
+	for _, q := range out.Queries {
+		fingerprint := q.Fingerprint
+		if len(fingerprint) > mysqlSlowTextFingerprint {
+			fingerprint = fingerprint[:mysqlSlowTextFingerprint] + "..."
+		}
+		fmt.Fprintf(&b, "\n%d. %s %.1f%% %.3fs total, %d calls, avg %.3fs, max %.3fs, lock %.3fs, rows examined %d\n   %s",
+			q.Rank, q.ID, q.TimePercent, q.TotalTime, q.Count, q.AvgTime, q.MaxTime, q.LockTime, q.RowsExamined, fingerprint)
+	}
+	return b.String()
+}
+
+func analyzeMySQLSlowLog(ctx context.Context, cfg *config.Config, in AnalyzeMySQLSlowLogInput) (AnalyzeMySQLSlowLogOutput, error) {
+	var problems validationErrors
+	switch {
+	case in.LocalPath == "" && in.InstanceID == "":
+		problems.addf("one of local_path or instance_id is required")
+	case in.LocalPath != "" && in.InstanceID != "":
+		problems.addf("local_path and instance_id cannot be used together")
+	case in.InstanceID != "" && in.Container == "":
+		problems.addf("container is required to export the log of instance %s", in.InstanceID)
+	}
+	sortBy := in.SortBy
+	if sortBy == "" {
+		sortBy = "total_time"
+	}
+	if _, ok := mysqlSlowSortKeys[sortBy]; !ok {
+		problems.addf("sort_by %q must be one of %s", in.SortBy, strings.Join(slices.Sorted(maps.Keys(mysqlSlowSortKeys)), ", "))
+	}
+	limit := in.Limit
+	if limit == 0 {
+		limit = mysqlSlowDefaultLimit
+	}
+	if limit < 1 || limit > mysqlSlowMaxLimit {
+		problems.addf("limit %d must be between 1 and %d", in.Limit, mysqlSlowMaxLimit)
+	}
+	timeout := mysqlLogExportTimeout
+	if in.TimeoutMinutes != 0 {
+		timeout = time.Duration(in.TimeoutMinutes) * time.Minute
+	}
+	if timeout <= 0 || timeout > mysqlLogExportMaxWait {
+		problems.addf("timeout_minutes %d must be between 1 and %d", in.TimeoutMinutes, int(mysqlLogExportMaxWait.Minutes()))
+	}
+	if err := problems.err(); err != nil {
+		return AnalyzeMySQLSlowLogOutput{}, err
+	}
+
+	var (
+		source string
+		body   io.ReadCloser
+	)
+	if in.LocalPath != "" {
+		f, err := os.Open(in.LocalPath)
+		if err != nil {
+			return AnalyzeMySQLSlowLogOutput{}, fmt.Errorf("failed to open %s: %w", in.LocalPath, err)
+		}
+		source, body = in.LocalPath, f
+	} else {
+		logFileName := in.LogFileName
+		if logFileName == "" {
+			files, err := listMySQLLogFiles(ctx, cfg, in.InstanceID)
+			if err != nil {
+				return AnalyzeMySQLSlowLogOutput{}, err
+			}
+			var latest *MySQLLogFile
+			for i, f := range files.LogFiles {
+				if f.Type == "slow_query" && (latest == nil || f.Updated > latest.Updated) {
+					latest = &files.LogFiles[i]
+				}
+			}
+			if latest == nil {
+				return AnalyzeMySQLSlowLogOutput{}, fmt.Errorf("instance %s has no slow query log; check that slow_query_log is enabled in its parameter group", in.InstanceID)
+			}
+			logFileName = latest.Name
+		}
+		exported, err := exportMySQLLogFile(ctx, cfg, in.InstanceID, logFileName, in.Container, in.ObjectPath, timeout)
+		if err != nil {
+			return AnalyzeMySQLSlowLogOutput{}, err
+		}
+		source, body = exported.location, exported.body
+	}
+	defer body.Close()
+
+	digest := newMySQLSlowDigest()
+	if err := parseMySQLSlowLog(body, digest.add); err != nil {
+		return AnalyzeMySQLSlowLogOutput{}, err
+	}
+	out := digest.report(sortBy, limit)
+	out.Source = source
+	return out, nil
+}
+
+// mysqlSlowEvent is one entry of a slow query log
+type mysqlSlowEvent struct {
+	time         time.Time
+	user         string
+	db           string
+	queryTime    float64
+	lockTime     float64
+	rowsSent     int64
+	rowsExamined int64
+	query        string
+}
+
+var (
+	mysqlSlowUserHost = regexp.MustCompile(`^# User@Host: ([^\[\s]*)`)
+	mysqlSlowMetric   = regexp.MustCompile(`(\w+): (\S+)`)
+	mysqlSlowUse      = regexp.MustCompile("(?i)^use\\s+`?([^`;\\s]+)`?\\s*;$")
+	mysqlSlowSetTime  = regexp.MustCompile(`(?i)^SET timestamp=(\d+);$`)
+)
+
+// parseMySQLSlowLog calls fn for every entry of a MySQL slow query log.
+// Entries are a run of "# " header lines (Time, User@Host, Query_time)
+// followed by the statement; server banner lines before the first
+// Query_time header are skipped.
+func parseMySQLSlowLog(r io.Reader, fn func(mysqlSlowEvent)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), mysqlLogMaxLineBytes)
+
+	var (
+		event      mysqlSlowEvent
+		hasMetrics bool
+		query      strings.Builder
+		db         string // use statements carry over to later entries
+	)
+	flush := func() {
+		if hasMetrics && query.Len() > 0 {
+			event.query = strings.TrimSpace(query.String())
+			if event.db == "" {
+				event.db = db
+			}
+			fn(event)
+		}
+		if query.Len() > 0 || hasMetrics {
+			event, hasMetrics = mysqlSlowEvent{}, false
+			query.Reset()
+		}
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, "#") {
+			if query.Len() > 0 {
+				flush()
+			}
+			switch {
+			case strings.HasPrefix(line, "# Time:"):
+				if t, ok := parseMySQLSlowTime(strings.TrimSpace(strings.TrimPrefix(line, "# Time:"))); ok {
+					event.time = t
+				}
+			case strings.HasPrefix(line, "# User@Host:"):
+				if m := mysqlSlowUserHost.FindStringSubmatch(line); m != nil {
+					event.user = m[1]
+				}
+			case strings.HasPrefix(line, "# administrator command:"):
+				if hasMetrics {
+					query.WriteString(strings.TrimSuffix(strings.TrimPrefix(line, "# "), ";"))
+				}
+			default:
+				for _, m := range mysqlSlowMetric.FindAllStringSubmatch(line, -1) {
+					switch m[1] {
+					case "Query_time":
+						event.queryTime, _ = strconv.ParseFloat(m[2], 64)
+						hasMetrics = true
+					case "Lock_time":
+						event.lockTime, _ = strconv.ParseFloat(m[2], 64)
+					case "Rows_sent":
+						event.rowsSent, _ = strconv.ParseInt(m[2], 10, 64)
+					case "Rows_examined":
+						event.rowsExamined, _ = strconv.ParseInt(m[2], 10, 64)
+					case "Schema":
+						event.db = m[2]
+					}
+				}
+			}
+			continue
+		}
+		if !hasMetrics {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if query.Len() == 0 {
+			if m := mysqlSlowUse.FindStringSubmatch(trimmed); m != nil {
+				db, event.db = m[1], m[1]
+				continue
+			}
+			if m := mysqlSlowSetTime.FindStringSubmatch(trimmed); m != nil {
+				if sec, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+					event.time = time.Unix(sec, 0).UTC()
+				}
+				continue
+			}
+			if trimmed == "" {
+				continue
+			}
+		}
+		query.WriteString(line)
+		query.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return fmt.Errorf("a line is longer than %d bytes", mysqlLogMaxLineBytes)
+		}
+		return fmt.Errorf("failed to read slow log: %w", err)
+	}
+	flush()
+	return nil
+}
+
+// parseMySQLSlowTime reads the "# Time:" header of MySQL 5.7+ (RFC 3339)
+// and of MySQL 5.6 (yymmdd hh:mm:ss)
+func parseMySQLSlowTime(s string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t.UTC(), true
+	}
+	if t, err := time.Parse("060102 15:04:05", strings.Join(strings.Fields(s), " ")); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+var mysqlFingerprintRules = []struct {
+	pattern *regexp.Regexp
+	replace string
+}{
+	{regexp.MustCompile(`'(?:[^'\\]|\\.|'')*'`), "?"},
+	{regexp.MustCompile(`"(?:[^"\\]|\\.|"")*"`), "?"},
+	{regexp.MustCompile(`(?s)/\*.*?\*/`), ""},
+	{regexp.MustCompile(`(?m)(?:--\s|#).*$`), ""},
+	{regexp.MustCompile(`\b0x[0-9a-f]+\b`), "?"},
+	{regexp.MustCompile(`\b\d+(?:\.\d+)?(?:e[+-]?\d+)?\b`), "?"},
+	{regexp.MustCompile(`([=<>(,]\s*)-\s*\?`), "$1?"},
+	{regexp.MustCompile(`\s+`), " "},
+	{regexp.MustCompile(`\bin ?\(\? ?(?:, ?\? ?)*\)`), "in(?+)"},
+	{regexp.MustCompile(`\bvalues ?\([?, ]*\)(?: ?, ?\([?, ]*\))*`), "values(?+)"},
+	{regexp.MustCompile(`\blimit \? ?, ?\?`), "limit ?"},
+}
+
+// mysqlQueryFingerprint normalizes a statement so that queries differing
+// only in literal values map to the same string
+func mysqlQueryFingerprint(query string) string {
+	fp := strings.ToLower(strings.TrimSpace(query))
+	for _, rule := range mysqlFingerprintRules {
+		fp = rule.pattern.ReplaceAllString(fp, rule.replace)
+	}
+	return strings.TrimSpace(strings.TrimRight(strings.TrimSpace(fp), ";"))
+}
+
+// mysqlQueryID derives a short stable ID from a fingerprint, in the 0x-prefixed
+// form pt-query-digest uses
+func mysqlQueryID(fingerprint string) string {
+	sum := md5.Sum([]byte(fingerprint))
+	return "0x" + strings.ToUpper(hex.EncodeToString(sum[8:]))
+}
+
+type mysqlSlowStats struct {
+	fingerprint  string
+	count        int
+	totalTime    float64
+	maxTime      float64
+	lockTime     float64
+	rowsSent     int64
+	rowsExamined int64
+	first, last  time.Time
+	databases    map[string]bool
+	users        map[string]bool
+	example      string
+}
+
+// mysqlSlowDigest aggregates slow log entries by fingerprint
+type mysqlSlowDigest struct {
+	queries   map[string]*mysqlSlowStats
+	events    int
+	totalTime float64
+	lockTime  float64
+	first     time.Time
+	last      time.Time
+}
+
+func newMySQLSlowDigest() *mysqlSlowDigest {
+	return &mysqlSlowDigest{queries: make(map[string]*mysqlSlowStats)}
+}
+
+func (d *mysqlSlowDigest) add(e mysqlSlowEvent) {
+	fp := mysqlQueryFingerprint(e.query)
+	s, ok := d.queries[fp]
+	if !ok {
+		s = &mysqlSlowStats{fingerprint: fp, databases: map[string]bool{}, users: map[string]bool{}}
+		d.queries[fp] = s
+	}
+	s.count++
+	s.totalTime += e.queryTime
+	s.lockTime += e.lockTime
+	s.rowsSent += e.rowsSent
+	s.rowsExamined += e.rowsExamined
+	if e.queryTime > s.maxTime || s.example == "" {
+		s.maxTime = max(s.maxTime, e.queryTime)
+		s.example = e.query
+	}
+	if e.db != "" {
+		s.databases[e.db] = true
+	}
+	if e.user != "" {
+		s.users[e.user] = true
+	}
+	if !e.time.IsZero() {
+		s.first, s.last = mysqlSlowEarliest(s.first, e.time), mysqlSlowLatest(s.last, e.time)
+		d.first, d.last = mysqlSlowEarliest(d.first, e.time), mysqlSlowLatest(d.last, e.time)
+	}
+
+	d.events++
+	d.totalTime += e.queryTime
+	d.lockTime += e.lockTime
+}
+
+func mysqlSlowEarliest(a, b time.Time) time.Time {
+	if a.IsZero() || b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func mysqlSlowLatest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// report ranks fingerprints by sortBy and returns the top limit of them
+func (d *mysqlSlowDigest) report(sortBy string, limit int) AnalyzeMySQLSlowLogOutput {
+	key := mysqlSlowSortKeys[sortBy]
+	stats := slices.Collect(maps.Values(d.queries))
+	slices.SortFunc(stats, func(a, b *mysqlSlowStats) int {
+		if c := cmp.Compare(key(b), key(a)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.fingerprint, b.fingerprint)
+	})
+
+	out := AnalyzeMySQLSlowLogOutput{
+		Events:        d.events,
+		UniqueQueries: len(stats),
+		TotalTime:     d.totalTime,
+		TotalLockTime: d.lockTime,
+		From:          formatMySQLSlowTime(d.first),
+		To:            formatMySQLSlowTime(d.last),
+		SortBy:        sortBy,
+		Queries:       []MySQLSlowQuery{},
+		Truncated:     len(stats) > limit,
+	}
+	for i, s := range stats[:min(limit, len(stats))] {
+		q := MySQLSlowQuery{
+			Rank:            i + 1,
+			ID:              mysqlQueryID(s.fingerprint),
+			Fingerprint:     s.fingerprint,
+			Count:           s.count,
+			TotalTime:       s.totalTime,
+			AvgTime:         s.totalTime / float64(s.count),
+			MaxTime:         s.maxTime,
+			LockTime:        s.lockTime,
+			RowsSent:        s.rowsSent,
+			RowsExamined:    s.rowsExamined,
+			AvgRowsExamined: float64(s.rowsExamined) / float64(s.count),
+			Databases:       slices.Sorted(maps.Keys(s.databases)),
+			Users:           slices.Sorted(maps.Keys(s.users)),
+			FirstSeen:       formatMySQLSlowTime(s.first),
+			LastSeen:        formatMySQLSlowTime(s.last),
+			Example:         s.example,
+		}
+		if d.totalTime > 0 {
+			q.TimePercent = 100 * s.totalTime / d.totalTime
+		}
+		if len(q.Example) > mysqlSlowMaxExample {
+			q.Example = q.Example[:mysqlSlowMaxExample] + "..."
+		}
+		out.Queries = append(out.Queries, q)
+	}
+	return out
+}
+
+func formatMySQLSlowTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
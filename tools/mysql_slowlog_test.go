@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestMySQLQueryFingerprint(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"SELECT * FROM t WHERE a = 1 AND b = 'x'", "select * from t where a = ? and b = ?"},
+		{"select * from t where b = \"it\\\"s\" and c = 'o''k'", "select * from t where b = ? and c = ?"},
+		{"SELECT a FROM t1 WHERE id IN (1, 2,3)", "select a from t1 where id in(?+)"},
+		{"SELECT  a\n  FROM t   /* hint */ WHERE x = -5;", "select a from t where x = ?"},
+		{"SELECT a FROM t WHERE x = 1.5e3 LIMIT 10, 20", "select a from t where x = ? limit ?"},
+		{"INSERT INTO t (a, b) VALUES (1, 'a'), (2, 'b')", "insert into t (a, b) values(?+)"},
+		{"SELECT * FROM t WHERE h = 0xFF -- trailing", "select * from t where h = ?"},
+		{"SELECT '#not a comment', col_2020 FROM t", "select ?, col_2020 from t"},
+	}
+	for _, tt := range tests {
+		if got := mysqlQueryFingerprint(tt.query); got != tt.want {
+			t.Errorf("mysqlQueryFingerprint(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func digestSampleSlowLog(t *testing.T) *mysqlSlowDigest {
+	t.Helper()
+	f, err := os.Open("testdata/mysql-slow.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	digest := newMySQLSlowDigest()
+	if err := parseMySQLSlowLog(f, digest.add); err != nil {
+		t.Fatal(err)
+	}
+	return digest
+}
+
+func TestMySQLSlowLogDigest(t *testing.T) {
+	out := digestSampleSlowLog(t).report("total_time", 10)
+
+	if out.Events != 7 || out.UniqueQueries != 4 {
+		t.Fatalf("got %d events and %d unique queries, want 7 and 4", out.Events, out.UniqueQueries)
+	}
+	if out.From != "2024-03-01T10:00:00Z" || out.To != "2024-03-01T10:10:00Z" {
+		t.Errorf("time range = %s..%s", out.From, out.To)
+	}
+
+	top := out.Queries[0]
+	if top.Fingerprint != "select * from orders where customer_id = ? and status = ?" {
+		t.Fatalf("top fingerprint = %q", top.Fingerprint)
+	}
+	if top.Count != 2 || top.TotalTime != 6 || top.MaxTime != 3.5 || top.RowsExamined != 1100000 {
+		t.Errorf("top stats = %+v", top)
+	}
+	if !strings.Contains(top.Example, "refunded") {
+		t.Errorf("example should be the slowest statement, got %q", top.Example)
+	}
+	if len(top.Databases) != 1 || top.Databases[0] != "shop" || len(top.Users) != 1 || top.Users[0] != "app" {
+		t.Errorf("databases %v, users %v", top.Databases, top.Users)
+	}
+	if !strings.HasPrefix(top.ID, "0x") || len(top.ID) != 18 {
+		t.Errorf("id = %q", top.ID)
+	}
+
+	for _, q := range out.Queries {
+		if q.Fingerprint == "administrator command: quit" {
+			return
+		}
+	}
+	t.Errorf("administrator command missing from %+v", out.Queries)
+}
+
+func TestMySQLSlowLogSortAndLimit(t *testing.T) {
+	digest := digestSampleSlowLog(t)
+
+	byCount := digest.report("count", 1)
+	if !byCount.Truncated || len(byCount.Queries) != 1 {
+		t.Fatalf("limit 1: truncated=%v, %d queries", byCount.Truncated, len(byCount.Queries))
+	}
+	products := byCount.Queries[0]
+	if products.Fingerprint != "select id, name from products where id in(?+)" || products.Count != 3 {
+		t.Errorf("top by count = %+v", products)
+	}
+	if products.Databases[0] != "analytics" {
+		t.Errorf("use statement should carry over, got %v", products.Databases)
+	}
+
+	if top := digest.report("lock_time", 10).Queries[0]; top.Fingerprint != products.Fingerprint {
+		t.Errorf("top by lock time = %q", top.Fingerprint)
+	}
+	if top := digest.report("rows_examined", 10).Queries[0]; top.RowsExamined != 1100000 {
+		t.Errorf("top by rows examined = %+v", top)
+	}
+}
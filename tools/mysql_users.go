@@ -0,0 +1,627 @@
+package tools
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/haung921209/nhn-cloud-sdk-go/nhncloud/rds/mysql"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DB user authority levels accepted by the RDS API
+var mysqlAuthorityTypes = []string{"READ", "CRUD", "DDL"}
+
+// mysqlSystemSchemas cannot be created or dropped through the API
+var mysqlSystemSchemas = []string{"mysql", "sys", "information_schema", "performance_schema", "rds_maintenance"}
+
+var (
+	mysqlUserHostPattern   = regexp.MustCompile(`^[A-Za-z0-9.%_:-]{1,255}$`)
+	mysqlSchemaNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]{1,64}$`)
+)
+
+// Generated passwords draw from each class so they satisfy MySQL's
+// validate_password policy. Quotes and backslashes are left out so the
+// password can be pasted into a shell or connection string unescaped.
+const (
+	mysqlPasswordLength  = 16
+	mysqlPasswordLower   = "abcdefghijkmnopqrstuvwxyz"
+	mysqlPasswordUpper   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	mysqlPasswordDigits  = "23456789"
+	mysqlPasswordSymbols = "!#%^*-_+="
+)
+
+// MySQLDBUser represents a database user on an instance
+type MySQLDBUser struct {
+	ID                   string `json:"id"`
+	Name                 string `json:"name"`
+	Host                 string `json:"host"`
+	AuthorityType        string `json:"authority_type"`
+	Status               string `json:"status"`
+	AuthenticationPlugin string `json:"authentication_plugin,omitempty"`
+	TLSOption            string `json:"tls_option,omitempty"`
+}
+
+// MySQLSchema represents a database schema on an instance
+type MySQLSchema struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Created string `json:"created,omitempty"`
+}
+
+type ListMySQLDBUsersInput struct {
+	InstanceID string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+}
+
+// ListMySQLDBUsersOutput - output for listing DB users
+type ListMySQLDBUsersOutput struct {
+	InstanceID string        `json:"instance_id"`
+	Users      []MySQLDBUser `json:"users"`
+	Count      int           `json:"count"`
+}
+
+type CreateMySQLDBUserInput struct {
+	InstanceID           string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+	Name                 string `json:"name" jsonschema_description:"User name (a letter followed by up to 31 letters, digits or '_')"`
+	Host                 string `json:"host,omitempty" jsonschema_description:"Host pattern the user may connect from, e.g. 10.0.0.% or % for any host (default %)"`
+	AuthorityType        string `json:"authority_type,omitempty" jsonschema_description:"READ, CRUD or DDL (default READ)"`
+	AuthenticationPlugin string `json:"authentication_plugin,omitempty" jsonschema_description:"Authentication plugin, e.g. NATIVE, SHA256 or CACHING_SHA2 (optional)"`
+	TLSOption            string `json:"tls_option,omitempty" jsonschema_description:"NONE, SSL or X509 (optional)"`
+}
+
+// CreateMySQLDBUserOutput - output for creating a DB user. The generated
+// password is deliberately not part of it; it appears once in the text content.
+type CreateMySQLDBUserOutput struct {
+	InstanceID    string `json:"instance_id"`
+	Name          string `json:"name"`
+	Host          string `json:"host"`
+	AuthorityType string `json:"authority_type"`
+	JobID         string `json:"job_id"`
+}
+
+type ModifyMySQLDBUserInput struct {
+	InstanceID    string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+	DBUserID      string `json:"db_user_id" jsonschema_description:"The ID of the DB user"`
+	AuthorityType string `json:"authority_type,omitempty" jsonschema_description:"New authority level: READ, CRUD or DDL (optional)"`
+	ResetPassword bool   `json:"reset_password,omitempty" jsonschema_description:"Generate a new password; it is shown once in the result"`
+}
+
+// ModifyMySQLDBUserOutput - output for modifying a DB user
+type ModifyMySQLDBUserOutput struct {
+	InstanceID    string `json:"instance_id"`
+	DBUserID      string `json:"db_user_id"`
+	Name          string `json:"name"`
+	AuthorityType string `json:"authority_type"`
+	PasswordReset bool   `json:"password_reset"`
+	JobID         string `json:"job_id"`
+}
+
+type DeleteMySQLDBUserInput struct {
+	InstanceID  string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+	DBUserID    string `json:"db_user_id" jsonschema_description:"The ID of the DB user"`
+	ConfirmName string `json:"confirm_name" jsonschema_description:"The user name, repeated to confirm deletion"`
+}
+
+type ListMySQLSchemasInput struct {
+	InstanceID string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+}
+
+// ListMySQLSchemasOutput - output for listing schemas
+type ListMySQLSchemasOutput struct {
+	InstanceID string        `json:"instance_id"`
+	Schemas    []MySQLSchema `json:"schemas"`
+	Count      int           `json:"count"`
+}
+
+type CreateMySQLSchemaInput struct {
+	InstanceID string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+	Name       string `json:"name" jsonschema_description:"Schema name (1-64 letters, digits or '_')"`
+}
+
+type DeleteMySQLSchemaInput struct {
+	InstanceID  string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+	SchemaID    string `json:"schema_id" jsonschema_description:"The ID of the schema"`
+	ConfirmName string `json:"confirm_name" jsonschema_description:"The schema name, repeated to confirm deletion. All data in the schema is lost."`
+}
+
+// MySQLUserChangeOutput - output for deleting DB users and for schema changes
+type MySQLUserChangeOutput struct {
+	InstanceID string `json:"instance_id"`
+	ID         string `json:"id,omitempty"`
+	Name       string `json:"name"`
+	Action     string `json:"action"`
+	JobID      string `json:"job_id,omitempty"`
+}
+
+func registerMySQLUserTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_list_db_users",
+		Description: "List the database users of an NHN Cloud RDS MySQL instance with their host pattern and authority level.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLDBUsersInput]) (*mcp.CallToolResultFor[ListMySQLDBUsersOutput], error) {
+		out, err := listMySQLDBUsers(ctx, cfg, params.Arguments.InstanceID)
+		if err != nil {
+			return &mcp.CallToolResultFor[ListMySQLDBUsersOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[ListMySQLDBUsersOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Found %d DB users", out.Count)}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_create_db_user",
+		Description: "Create a database user on an NHN Cloud RDS MySQL instance. A strong password is generated by the server and shown exactly once in the result text; it cannot be retrieved later, only reset with nhn_mysql_modify_db_user.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[CreateMySQLDBUserInput]) (*mcp.CallToolResultFor[CreateMySQLDBUserOutput], error) {
+		out, password, err := createMySQLDBUser(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[CreateMySQLDBUserOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		text := fmt.Sprintf("Creating DB user %s@'%s' with %s authority (job %s)\n%s",
+			out.Name, out.Host, out.AuthorityType, out.JobID, mysqlPasswordNotice(ctx, password))
+		return &mcp.CallToolResultFor[CreateMySQLDBUserOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_modify_db_user",
+		Description: "Change the authority level of a database user on an NHN Cloud RDS MySQL instance, or reset its password to a new server-generated one that is shown once in the result text.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ModifyMySQLDBUserInput]) (*mcp.CallToolResultFor[ModifyMySQLDBUserOutput], error) {
+		out, password, err := modifyMySQLDBUser(ctx, cfg, params.Arguments)
+		if err != nil {
+			return &mcp.CallToolResultFor[ModifyMySQLDBUserOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		text := fmt.Sprintf("Modifying DB user %s: authority %s (job %s)", out.Name, out.AuthorityType, out.JobID)
+		if password != "" {
+			text += "\n" + mysqlPasswordNotice(ctx, password)
+		}
+		return &mcp.CallToolResultFor[ModifyMySQLDBUserOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_delete_db_user",
+		Description: "Delete a database user from an NHN Cloud RDS MySQL instance. confirm_name must repeat the user name.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[DeleteMySQLDBUserInput]) (*mcp.CallToolResultFor[MySQLUserChangeOutput], error) {
+		out, err := deleteMySQLDBUser(ctx, cfg, params.Arguments)
+		return mysqlUserChangeResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_list_schemas",
+		Description: "List the database schemas of an NHN Cloud RDS MySQL instance.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMySQLSchemasInput]) (*mcp.CallToolResultFor[ListMySQLSchemasOutput], error) {
+		out, err := listMySQLSchemas(ctx, cfg, params.Arguments.InstanceID)
+		if err != nil {
+			return &mcp.CallToolResultFor[ListMySQLSchemasOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+		return &mcp.CallToolResultFor[ListMySQLSchemasOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Found %d schemas", out.Count)}},
+			StructuredContent: out,
+		}, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_create_schema",
+		Description: "Create a database schema on an NHN Cloud RDS MySQL instance.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[CreateMySQLSchemaInput]) (*mcp.CallToolResultFor[MySQLUserChangeOutput], error) {
+		out, err := createMySQLSchema(ctx, cfg, params.Arguments.InstanceID, params.Arguments.Name)
+		return mysqlUserChangeResult(out, err)
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_delete_schema",
+		Description: "Drop a database schema and all its data from an NHN Cloud RDS MySQL instance. confirm_name must repeat the schema name.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[DeleteMySQLSchemaInput]) (*mcp.CallToolResultFor[MySQLUserChangeOutput], error) {
+		out, err := deleteMySQLSchema(ctx, cfg, params.Arguments)
+		return mysqlUserChangeResult(out, err)
+	})
+}
+
+func mysqlUserChangeResult(out MySQLUserChangeOutput, err error) (*mcp.CallToolResultFor[MySQLUserChangeOutput], error) {
+	if err != nil {
+		return &mcp.CallToolResultFor[MySQLUserChangeOutput]{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+			IsError: true,
+		}, nil
+	}
+
+	text := fmt.Sprintf("%s %s on instance %s", out.Action, out.Name, out.InstanceID)
+	if out.JobID != "" {
+		text += fmt.Sprintf(" (job %s)", out.JobID)
+	}
+	return &mcp.CallToolResultFor[MySQLUserChangeOutput]{
+		Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+		StructuredContent: out,
+	}, nil
+}
+
+// mysqlPasswordNotice is the only place a generated password is written out.
+// It exempts the password from redaction for this one result, since this is
+// the only copy the user gets.
+func mysqlPasswordNotice(ctx context.Context, password string) string {
+	revealOnce(ctx, password)
+	return fmt.Sprintf("Generated password: %s (shown only this once, store it now)", password)
+}
+
+func listMySQLDBUsers(ctx context.Context, cfg *config.Config, instanceID string) (ListMySQLDBUsersOutput, error) {
+	if instanceID == "" {
+		return ListMySQLDBUsersOutput{}, fmt.Errorf("instance_id is required")
+	}
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return ListMySQLDBUsersOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	users, err := fetchMySQLDBUsers(ctx, client.MySQL(), instanceID)
+	if err != nil {
+		return ListMySQLDBUsersOutput{}, err
+	}
+	return ListMySQLDBUsersOutput{InstanceID: instanceID, Users: users, Count: len(users)}, nil
+}
+
+func createMySQLDBUser(ctx context.Context, cfg *config.Config, in CreateMySQLDBUserInput) (CreateMySQLDBUserOutput, string, error) {
+	host := in.Host
+	if host == "" {
+		host = "%"
+	}
+	authority := strings.ToUpper(in.AuthorityType)
+	if authority == "" {
+		authority = "READ"
+	}
+
+	var problems validationErrors
+	if in.InstanceID == "" {
+		problems.addf("instance_id is required")
+	}
+	if !mysqlUserNamePattern.MatchString(in.Name) {
+		problems.addf("name %q must start with a letter and have at most 32 letters, digits or '_'", in.Name)
+	}
+	if !mysqlUserHostPattern.MatchString(host) {
+		problems.addf("host %q must be a host name or IP address, optionally with %% wildcards", in.Host)
+	}
+	if !slices.Contains(mysqlAuthorityTypes, authority) {
+		problems.addf("authority_type %q must be one of %s", in.AuthorityType, strings.Join(mysqlAuthorityTypes, ", "))
+	}
+	if err := problems.err(); err != nil {
+		return CreateMySQLDBUserOutput{}, "", err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return CreateMySQLDBUserOutput{}, "", fmt.Errorf("failed to create client: %w", err)
+	}
+	rds := client.MySQL()
+
+	existing, err := fetchMySQLDBUsers(ctx, rds, in.InstanceID)
+	if err != nil {
+		return CreateMySQLDBUserOutput{}, "", err
+	}
+	for _, u := range existing {
+		if u.Name == in.Name && u.Host == host {
+			return CreateMySQLDBUserOutput{}, "", fmt.Errorf("DB user %s@'%s' already exists (%s)", in.Name, host, u.ID)
+		}
+	}
+
+	password, err := generateMySQLPassword()
+	if err != nil {
+		return CreateMySQLDBUserOutput{}, "", err
+	}
+	result, err := rds.CreateDBUser(ctx, in.InstanceID, &mysql.CreateDBUserInput{
+		DBUserName:           in.Name,
+		DBPassword:           password,
+		Host:                 host,
+		AuthorityType:        authority,
+		AuthenticationPlugin: strings.ToUpper(in.AuthenticationPlugin),
+		TLSOption:            strings.ToUpper(in.TLSOption),
+	})
+	if err != nil {
+		return CreateMySQLDBUserOutput{}, "", fmt.Errorf("failed to create DB user: %w", err)
+	}
+
+	return CreateMySQLDBUserOutput{
+		InstanceID:    in.InstanceID,
+		Name:          in.Name,
+		Host:          host,
+		AuthorityType: authority,
+		JobID:         result.JobID,
+	}, password, nil
+}
+
+func modifyMySQLDBUser(ctx context.Context, cfg *config.Config, in ModifyMySQLDBUserInput) (ModifyMySQLDBUserOutput, string, error) {
+	authority := strings.ToUpper(in.AuthorityType)
+
+	var problems validationErrors
+	if in.InstanceID == "" || in.DBUserID == "" {
+		problems.addf("instance_id and db_user_id are required")
+	}
+	if authority == "" && !in.ResetPassword {
+		problems.addf("set authority_type or reset_password")
+	}
+	if authority != "" && !slices.Contains(mysqlAuthorityTypes, authority) {
+		problems.addf("authority_type %q must be one of %s", in.AuthorityType, strings.Join(mysqlAuthorityTypes, ", "))
+	}
+	if err := problems.err(); err != nil {
+		return ModifyMySQLDBUserOutput{}, "", err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return ModifyMySQLDBUserOutput{}, "", fmt.Errorf("failed to create client: %w", err)
+	}
+	user, err := findMySQLDBUser(ctx, client.MySQL(), in.InstanceID, in.DBUserID)
+	if err != nil {
+		return ModifyMySQLDBUserOutput{}, "", err
+	}
+	if authority == "" {
+		authority = user.AuthorityType
+	}
+
+	// The SDK's update request has no password field, so go through the API directly
+	body := struct {
+		DBPassword    string `json:"dbPassword,omitempty"`
+		AuthorityType string `json:"authorityType,omitempty"`
+	}{AuthorityType: authority}
+	if in.ResetPassword {
+		if body.DBPassword, err = generateMySQLPassword(); err != nil {
+			return ModifyMySQLDBUserOutput{}, "", err
+		}
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return ModifyMySQLDBUserOutput{}, "", err
+	}
+	var job mysqlAPIJob
+	path := fmt.Sprintf("/db-instances/%s/db-users/%s", url.PathEscape(in.InstanceID), url.PathEscape(in.DBUserID))
+	if err := api.put(ctx, path, body, &job); err != nil {
+		return ModifyMySQLDBUserOutput{}, "", fmt.Errorf("failed to modify DB user: %w", err)
+	}
+
+	return ModifyMySQLDBUserOutput{
+		InstanceID:    in.InstanceID,
+		DBUserID:      in.DBUserID,
+		Name:          user.Name,
+		AuthorityType: authority,
+		PasswordReset: in.ResetPassword,
+		JobID:         job.JobID,
+	}, body.DBPassword, nil
+}
+
+func deleteMySQLDBUser(ctx context.Context, cfg *config.Config, in DeleteMySQLDBUserInput) (MySQLUserChangeOutput, error) {
+	if in.InstanceID == "" || in.DBUserID == "" {
+		return MySQLUserChangeOutput{}, fmt.Errorf("instance_id and db_user_id are required")
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLUserChangeOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	rds := client.MySQL()
+
+	user, err := findMySQLDBUser(ctx, rds, in.InstanceID, in.DBUserID)
+	if err != nil {
+		return MySQLUserChangeOutput{}, err
+	}
+	if err := checkMySQLConfirmName("deletion", "DB user "+in.DBUserID, in.ConfirmName, user.Name); err != nil {
+		return MySQLUserChangeOutput{}, err
+	}
+
+	result, err := rds.DeleteDBUser(ctx, in.InstanceID, in.DBUserID)
+	if err != nil {
+		return MySQLUserChangeOutput{}, fmt.Errorf("failed to delete DB user: %w", err)
+	}
+	return MySQLUserChangeOutput{
+		InstanceID: in.InstanceID,
+		ID:         in.DBUserID,
+		Name:       fmt.Sprintf("%s@'%s'", user.Name, user.Host),
+		Action:     "Deleting DB user",
+		JobID:      result.JobID,
+	}, nil
+}
+
+func listMySQLSchemas(ctx context.Context, cfg *config.Config, instanceID string) (ListMySQLSchemasOutput, error) {
+	if instanceID == "" {
+		return ListMySQLSchemasOutput{}, fmt.Errorf("instance_id is required")
+	}
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return ListMySQLSchemasOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	schemas, err := fetchMySQLSchemas(ctx, client.MySQL(), instanceID)
+	if err != nil {
+		return ListMySQLSchemasOutput{}, err
+	}
+	return ListMySQLSchemasOutput{InstanceID: instanceID, Schemas: schemas, Count: len(schemas)}, nil
+}
+
+func createMySQLSchema(ctx context.Context, cfg *config.Config, instanceID, name string) (MySQLUserChangeOutput, error) {
+	var problems validationErrors
+	if instanceID == "" {
+		problems.addf("instance_id is required")
+	}
+	if !mysqlSchemaNamePattern.MatchString(name) {
+		problems.addf("name %q must be 1-64 letters, digits or '_'", name)
+	}
+	if slices.Contains(mysqlSystemSchemas, strings.ToLower(name)) {
+		problems.addf("%s is a system schema", name)
+	}
+	if err := problems.err(); err != nil {
+		return MySQLUserChangeOutput{}, err
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLUserChangeOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	rds := client.MySQL()
+
+	existing, err := fetchMySQLSchemas(ctx, rds, instanceID)
+	if err != nil {
+		return MySQLUserChangeOutput{}, err
+	}
+	for _, s := range existing {
+		if s.Name == name {
+			return MySQLUserChangeOutput{}, fmt.Errorf("schema %s already exists (%s)", name, s.ID)
+		}
+	}
+
+	result, err := rds.CreateSchema(ctx, instanceID, &mysql.CreateSchemaInput{DBSchemaName: name})
+	if err != nil {
+		return MySQLUserChangeOutput{}, fmt.Errorf("failed to create schema: %w", err)
+	}
+	return MySQLUserChangeOutput{
+		InstanceID: instanceID,
+		ID:         result.DBSchemaID,
+		Name:       name,
+		Action:     "Creating schema",
+		JobID:      result.JobID,
+	}, nil
+}
+
+func deleteMySQLSchema(ctx context.Context, cfg *config.Config, in DeleteMySQLSchemaInput) (MySQLUserChangeOutput, error) {
+	if in.InstanceID == "" || in.SchemaID == "" {
+		return MySQLUserChangeOutput{}, fmt.Errorf("instance_id and schema_id are required")
+	}
+
+	client, err := cfg.NewNHNCloudClient()
+	if err != nil {
+		return MySQLUserChangeOutput{}, fmt.Errorf("failed to create client: %w", err)
+	}
+	rds := client.MySQL()
+
+	schemas, err := fetchMySQLSchemas(ctx, rds, in.InstanceID)
+	if err != nil {
+		return MySQLUserChangeOutput{}, err
+	}
+	idx := slices.IndexFunc(schemas, func(s MySQLSchema) bool { return s.ID == in.SchemaID })
+	if idx < 0 {
+		return MySQLUserChangeOutput{}, fmt.Errorf("schema %s not found on instance %s", in.SchemaID, in.InstanceID)
+	}
+	schema := schemas[idx]
+	if err := checkMySQLConfirmName("deletion", "schema "+in.SchemaID, in.ConfirmName, schema.Name); err != nil {
+		return MySQLUserChangeOutput{}, err
+	}
+
+	result, err := rds.DeleteSchema(ctx, in.InstanceID, in.SchemaID)
+	if err != nil {
+		return MySQLUserChangeOutput{}, fmt.Errorf("failed to delete schema: %w", err)
+	}
+	return MySQLUserChangeOutput{
+		InstanceID: in.InstanceID,
+		ID:         in.SchemaID,
+		Name:       schema.Name,
+		Action:     "Dropping schema",
+		JobID:      result.JobID,
+	}, nil
+}
+
+func fetchMySQLDBUsers(ctx context.Context, rds *mysql.Client, instanceID string) ([]MySQLDBUser, error) {
+	result, err := rds.ListDBUsers(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DB users: %w", err)
+	}
+
+	users := make([]MySQLDBUser, 0, len(result.DBUsers))
+	for _, u := range result.DBUsers {
+		users = append(users, MySQLDBUser{
+			ID:                   u.DBUserID,
+			Name:                 u.DBUserName,
+			Host:                 u.Host,
+			AuthorityType:        u.AuthorityType,
+			Status:               u.DBUserStatus,
+			AuthenticationPlugin: u.AuthenticationPlugin,
+			TLSOption:            u.TLSOption,
+		})
+	}
+	return users, nil
+}
+
+func findMySQLDBUser(ctx context.Context, rds *mysql.Client, instanceID, dbUserID string) (MySQLDBUser, error) {
+	users, err := fetchMySQLDBUsers(ctx, rds, instanceID)
+	if err != nil {
+		return MySQLDBUser{}, err
+	}
+	for _, u := range users {
+		if u.ID == dbUserID {
+			return u, nil
+		}
+	}
+	return MySQLDBUser{}, fmt.Errorf("DB user %s not found on instance %s", dbUserID, instanceID)
+}
+
+func fetchMySQLSchemas(ctx context.Context, rds *mysql.Client, instanceID string) ([]MySQLSchema, error) {
+	result, err := rds.ListSchemas(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schemas: %w", err)
+	}
+
+	schemas := make([]MySQLSchema, 0, len(result.DBSchemas))
+	for _, s := range result.DBSchemas {
+		schemas = append(schemas, MySQLSchema{ID: s.DBSchemaId, Name: s.DBSchemaName, Created: s.CreatedYmdt})
+	}
+	return schemas, nil
+}
+
+// generateMySQLPassword returns a random password with at least one character
+// from every class
+func generateMySQLPassword() (string, error) {
+	classes := []string{mysqlPasswordLower, mysqlPasswordUpper, mysqlPasswordDigits, mysqlPasswordSymbols}
+	all := strings.Join(classes, "")
+
+	pick := func(set string) (byte, error) {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(set))))
+		if err != nil {
+			return 0, fmt.Errorf("failed to generate password: %w", err)
+		}
+		return set[n.Int64()], nil
+	}
+
+	password := make([]byte, mysqlPasswordLength)
+	for i := range password {
+		set := all
+		if i < len(classes) {
+			set = classes[i]
+		}
+		c, err := pick(set)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+
+	// Shuffle so the guaranteed characters are not always first
+	for i := len(password) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate password: %w", err)
+		}
+		j := n.Int64()
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
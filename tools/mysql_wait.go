@@ -0,0 +1,201 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	mysqlWaitDefaultTimeout  = 30 * time.Minute
+	mysqlWaitMaxTimeout      = 2 * time.Hour
+	mysqlWaitDefaultInterval = 15 * time.Second
+	mysqlWaitMinInterval     = 5 * time.Second
+	mysqlWaitMaxInterval     = 5 * time.Minute
+)
+
+// mysqlFailureStatuses need intervention to leave, so waiting past them is pointless
+var mysqlFailureStatuses = []string{
+	mysqlStatusFailToCreate, mysqlStatusFailToConnect, mysqlStatusStorageFull, mysqlStatusReplicationStop,
+}
+
+// mysqlWaitTargetStatuses are the statuses an instance can be waited for
+var mysqlWaitTargetStatuses = []string{
+	mysqlStatusAvailable, mysqlStatusBeforeCreate, mysqlStatusStorageFull, mysqlStatusFailToCreate, mysqlStatusFailToConnect,
+	mysqlStatusReplicationStop, mysqlStatusFailover, mysqlStatusShutdown, mysqlStatusDeleted,
+}
+
+// mysqlInstanceState is the part of GET /db-instances/{id} the wait tool
+// needs. progressStatus is not exposed by the SDK; it is NONE when no
+// operation is running on the instance.
+type mysqlInstanceState struct {
+	DBInstanceStatus string `json:"dbInstanceStatus"`
+	ProgressStatus   string `json:"progressStatus"`
+}
+
+func (s mysqlInstanceState) idle() bool {
+	return s.ProgressStatus == "" || s.ProgressStatus == "NONE"
+}
+
+type WaitForMySQLInstanceInput struct {
+	InstanceID          string `json:"instance_id" jsonschema_description:"The ID of the MySQL instance"`
+	TargetStatus        string `json:"target_status,omitempty" jsonschema_description:"Status to wait for, e.g. AVAILABLE, SHUTDOWN or DELETED (default AVAILABLE)"`
+	TimeoutMinutes      int    `json:"timeout_minutes,omitempty" jsonschema_description:"Maximum time to wait (default 30, max 120)"`
+	PollIntervalSeconds int    `json:"poll_interval_seconds,omitempty" jsonschema_description:"Seconds between status checks (default 15, 5-300)"`
+}
+
+// MySQLStatusTransition is one observed instance state
+type MySQLStatusTransition struct {
+	Time           string `json:"time"`
+	ElapsedSeconds int    `json:"elapsed_seconds"`
+	Status         string `json:"status"`
+	ProgressStatus string `json:"progress_status,omitempty"`
+}
+
+// WaitForMySQLInstanceOutput - output for waiting on an instance status
+type WaitForMySQLInstanceOutput struct {
+	InstanceID     string                  `json:"instance_id"`
+	TargetStatus   string                  `json:"target_status"`
+	FinalStatus    string                  `json:"final_status"`
+	Reached        bool                    `json:"reached"`
+	Failed         bool                    `json:"failed"`
+	TimedOut       bool                    `json:"timed_out"`
+	ElapsedSeconds int                     `json:"elapsed_seconds"`
+	Timeline       []MySQLStatusTransition `json:"timeline"`
+}
+
+func registerMySQLWaitTools(server *mcp.Server, cfg *config.Config) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "nhn_mysql_wait_for_instance",
+		Description: "Wait until an NHN Cloud RDS MySQL instance reaches a target status (default AVAILABLE) with no operation in progress, a failure status, or the timeout. Sends a progress notification on every status change and returns the full transition timeline. Use this instead of polling nhn_mysql_get_instance.",
+	}, func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[WaitForMySQLInstanceInput]) (*mcp.CallToolResultFor[WaitForMySQLInstanceOutput], error) {
+		notify := func(step int, t MySQLStatusTransition) {
+			token := params.GetProgressToken()
+			if token == nil {
+				return
+			}
+			msg := fmt.Sprintf("%s after %ds", t.Status, t.ElapsedSeconds)
+			if t.ProgressStatus != "" {
+				msg = fmt.Sprintf("%s (%s) after %ds", t.Status, t.ProgressStatus, t.ElapsedSeconds)
+			}
+			_ = ss.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+				ProgressToken: token,
+				Progress:      float64(step),
+				Message:       msg,
+			})
+		}
+
+		out, err := waitForMySQLInstance(ctx, cfg, params.Arguments, notify)
+		if err != nil {
+			return &mcp.CallToolResultFor[WaitForMySQLInstanceOutput]{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
+				IsError: true,
+			}, nil
+		}
+
+		var text string
+		switch {
+		case out.Reached:
+			text = fmt.Sprintf("Instance %s reached %s after %ds (%d transitions)", out.InstanceID, out.TargetStatus, out.ElapsedSeconds, len(out.Timeline))
+		case out.Failed:
+			text = fmt.Sprintf("Instance %s entered failure status %s after %ds instead of %s", out.InstanceID, out.FinalStatus, out.ElapsedSeconds, out.TargetStatus)
+		default:
+			text = fmt.Sprintf("Timed out after %ds waiting for instance %s to reach %s; last status %s", out.ElapsedSeconds, out.InstanceID, out.TargetStatus, out.FinalStatus)
+		}
+		return &mcp.CallToolResultFor[WaitForMySQLInstanceOutput]{
+			Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+			StructuredContent: out,
+		}, nil
+	})
+}
+
+func waitForMySQLInstance(ctx context.Context, cfg *config.Config, in WaitForMySQLInstanceInput, notify func(step int, t MySQLStatusTransition)) (WaitForMySQLInstanceOutput, error) {
+	if in.InstanceID == "" {
+		return WaitForMySQLInstanceOutput{}, fmt.Errorf("instance_id is required")
+	}
+	target := strings.ToUpper(strings.TrimSpace(in.TargetStatus))
+	if target == "" {
+		target = mysqlStatusAvailable
+	}
+	timeout := mysqlWaitDefaultTimeout
+	if in.TimeoutMinutes != 0 {
+		timeout = time.Duration(in.TimeoutMinutes) * time.Minute
+	}
+	interval := mysqlWaitDefaultInterval
+	if in.PollIntervalSeconds != 0 {
+		interval = time.Duration(in.PollIntervalSeconds) * time.Second
+	}
+
+	var problems validationErrors
+	if !slices.Contains(mysqlWaitTargetStatuses, target) {
+		problems.addf("target_status %q must be one of %s", in.TargetStatus, strings.Join(mysqlWaitTargetStatuses, ", "))
+	}
+	if timeout <= 0 || timeout > mysqlWaitMaxTimeout {
+		problems.addf("timeout_minutes %d must be between 1 and %d", in.TimeoutMinutes, int(mysqlWaitMaxTimeout.Minutes()))
+	}
+	if interval < mysqlWaitMinInterval || interval > mysqlWaitMaxInterval {
+		problems.addf("poll_interval_seconds %d must be between %d and %d", in.PollIntervalSeconds, int(mysqlWaitMinInterval.Seconds()), int(mysqlWaitMaxInterval.Seconds()))
+	}
+	if err := problems.err(); err != nil {
+		return WaitForMySQLInstanceOutput{}, err
+	}
+
+	api, err := newMySQLAPI(cfg)
+	if err != nil {
+		return WaitForMySQLInstanceOutput{}, err
+	}
+
+	out := WaitForMySQLInstanceOutput{InstanceID: in.InstanceID, TargetStatus: target}
+	start := time.Now()
+	var last mysqlInstanceState
+	for {
+		var state mysqlInstanceState
+		if err := api.get(ctx, "/db-instances/"+url.PathEscape(in.InstanceID), nil, &state); err != nil {
+			// A deleted instance stops being found once deletion completes
+			if target == mysqlStatusDeleted && len(out.Timeline) > 0 && isMySQLNotFound(err) {
+				state = mysqlInstanceState{DBInstanceStatus: mysqlStatusDeleted}
+			} else {
+				return WaitForMySQLInstanceOutput{}, fmt.Errorf("failed to get instance: %w", err)
+			}
+		}
+
+		now := time.Now()
+		out.ElapsedSeconds = int(now.Sub(start).Seconds())
+		out.FinalStatus = state.DBInstanceStatus
+		if len(out.Timeline) == 0 || state != last {
+			t := MySQLStatusTransition{
+				Time:           now.Format(time.RFC3339),
+				ElapsedSeconds: out.ElapsedSeconds,
+				Status:         state.DBInstanceStatus,
+				ProgressStatus: state.ProgressStatus,
+			}
+			out.Timeline = append(out.Timeline, t)
+			notify(len(out.Timeline), t)
+			last = state
+		}
+
+		switch {
+		case state.DBInstanceStatus == target && (state.idle() || target == mysqlStatusDeleted):
+			out.Reached = true
+			return out, nil
+		case slices.Contains(mysqlFailureStatuses, state.DBInstanceStatus) && state.idle():
+			out.Failed = true
+			return out, nil
+		case now.Add(interval).Sub(start) > timeout:
+			out.TimedOut = true
+			return out, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return out, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
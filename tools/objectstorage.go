@@ -0,0 +1,129 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+)
+
+// objectStorageURLFormat is the Object Storage account endpoint for a region and tenant
+const objectStorageURLFormat = "https://%s-api-object-storage.nhncloudservice.com/v1/AUTH_%s"
+
+// objectStorageObject is one entry of a container listing
+type objectStorageObject struct {
+	Name  string `json:"name"`
+	Bytes int64  `json:"bytes"`
+	Hash  string `json:"hash"`
+}
+
+// objectStorageURL returns the URL of container/objectPath for the configured tenant
+func objectStorageURL(cfg *config.Config, container, objectPath string) string {
+	region, tenantID, _, _ := cfg.ObjectStorageCredentials()
+	u := fmt.Sprintf(objectStorageURLFormat, strings.ToLower(region), tenantID) + "/" + url.PathEscape(container)
+	if objectPath != "" {
+		u += "/" + strings.TrimPrefix(objectPath, "/")
+	}
+	return u
+}
+
+// listObjectStorageObjects lists the objects in container whose names start
+// with prefix. The SDK's object client cannot be used because it escapes the
+// query string into the path.
+func listObjectStorageObjects(ctx context.Context, cfg *config.Config, container, prefix string) ([]objectStorageObject, error) {
+	_, tenantID, _, _ := cfg.ObjectStorageCredentials()
+	token, err := cfg.IdentityToken(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []objectStorageObject
+	marker := ""
+	for {
+		query := url.Values{"format": {"json"}}
+		if prefix != "" {
+			query.Set("prefix", strings.TrimPrefix(prefix, "/"))
+		}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, objectStorageURL(cfg, container, "")+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-Auth-Token", token)
+
+		resp, err := (&http.Client{Timeout: 60 * time.Second}).Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list container %s: %w", container, err)
+		}
+		var page []objectStorageObject
+		switch {
+		case resp.StatusCode == http.StatusNotFound:
+			err = fmt.Errorf("container %q not found", container)
+		case resp.StatusCode == http.StatusNoContent:
+			// empty listing
+		case resp.StatusCode >= 300:
+			err = fmt.Errorf("failed to list container %s: HTTP %d", container, resp.StatusCode)
+		default:
+			err = json.NewDecoder(resp.Body).Decode(&page)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, page...)
+		if len(page) == 0 {
+			return all, nil
+		}
+		marker = page[len(page)-1].Name
+	}
+}
+
+// openObjectStorageObject opens container/name for reading. The caller closes
+// the returned body.
+func openObjectStorageObject(ctx context.Context, cfg *config.Config, container, name string) (io.ReadCloser, int64, error) {
+	_, tenantID, _, _ := cfg.ObjectStorageCredentials()
+	token, err := cfg.IdentityToken(ctx, tenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, objectStorageURL(cfg, container, objectStoragePath(name)), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("X-Auth-Token", token)
+
+	// No client timeout: large objects may take longer than any fixed limit; ctx bounds the request
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to download %s/%s: %w", container, name, err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("object %s/%s not found", container, name)
+	case resp.StatusCode >= 300:
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("failed to download %s/%s: HTTP %d", container, name, resp.StatusCode)
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+// objectStoragePath escapes each segment of an object name
+func objectStoragePath(name string) string {
+	segments := strings.Split(strings.TrimPrefix(name, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,216 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/haung921209/nhn-cloud-mcp/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// redacted replaces every masked value in tool output
+const redacted = "[REDACTED]"
+
+// minSecretLength avoids masking short values that would match ordinary text
+const minSecretLength = 4
+
+// secretPatterns catch secrets that are not in the config, such as DB user
+// passwords or tokens echoed back in SDK error messages
+var secretPatterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	// key=value, key: value and "key": "value" for secret-looking keys
+	{
+		regexp.MustCompile(`(?i)("?\b[\w-]*(?:password|passwd|secret|access_token|auth_token|x-auth-token|appkey|app_key)"?\s*[:=]\s*)("(?:[^"\\]|\\.)*"|[^\s,;&}"]+)`),
+		`${1}"` + redacted + `"`,
+	},
+	// Authorization headers
+	{regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9\-._~+/]{8,}=*`), `${1} ` + redacted},
+	// Credentials embedded in URLs
+	{regexp.MustCompile(`(://[^/\s:@]+:)[^/\s@]+@`), `${1}` + redacted + `@`},
+}
+
+// secretFieldNames lists structured-content keys whose values are always masked
+var secretFieldNames = []string{"password", "passwd", "secret", "access_token", "auth_token", "x-auth-token", "appkey", "app_key"}
+
+// Redactor masks secrets in tool results
+type Redactor struct {
+	values func() []string
+}
+
+// NewRedactor creates a Redactor that masks the secret credentials of cfg,
+// read at redaction time so runtime changes via nhn_set_credential apply.
+func NewRedactor(cfg *config.Config) *Redactor {
+	return &Redactor{values: cfg.SecretValues}
+}
+
+// Middleware returns MCP server middleware that redacts every tools/call
+// result before it is sent to the client. Install it with
+// server.AddReceivingMiddleware so that it covers all registered tools.
+func (r *Redactor) Middleware() mcp.Middleware[*mcp.ServerSession] {
+	return func(next mcp.MethodHandler[*mcp.ServerSession]) mcp.MethodHandler[*mcp.ServerSession] {
+		return func(ctx context.Context, ss *mcp.ServerSession, method string, params mcp.Params) (mcp.Result, error) {
+			revealed := &revealedValues{}
+			res, err := next(context.WithValue(ctx, revealedValuesKey{}, revealed), ss, method, params)
+			if result, ok := res.(*mcp.CallToolResult); ok && result != nil {
+				r.redactResult(result, revealed.list())
+			}
+			return res, err
+		}
+	}
+}
+
+// revealedValuesKey is the context key of the values a tool call may reveal
+type revealedValuesKey struct{}
+
+// revealedValues collects the values passed to revealOnce during one call
+type revealedValues struct {
+	mu     sync.Mutex
+	values []string
+}
+
+func (v *revealedValues) list() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return slices.Clone(v.values)
+}
+
+// revealOnce lets value appear in the text content of the result of the
+// current tool call, however secret it looks. It is meant for values created
+// for the user that cannot be read back later, such as generated passwords.
+// Structured content is still masked, and outside the redactor middleware
+// the value is masked as usual.
+func revealOnce(ctx context.Context, value string) {
+	if v, ok := ctx.Value(revealedValuesKey{}).(*revealedValues); ok && value != "" {
+		v.mu.Lock()
+		v.values = append(v.values, value)
+		v.mu.Unlock()
+	}
+}
+
+// RedactResult masks secrets in the text content and structured content of result
+func (r *Redactor) RedactResult(result *mcp.CallToolResult) {
+	r.redactResult(result, nil)
+}
+
+// redactResult masks secrets in result, leaving the revealed values in its
+// text content. Configured credentials are never revealed.
+func (r *Redactor) redactResult(result *mcp.CallToolResult, revealed []string) {
+	secrets := r.secrets()
+	revealed = slices.DeleteFunc(revealed, func(v string) bool {
+		return slices.ContainsFunc(secrets, func(s string) bool { return strings.Contains(v, s) })
+	})
+
+	for _, c := range result.Content {
+		if t, ok := c.(*mcp.TextContent); ok {
+			t.Text = redactTextRevealing(t.Text, secrets, revealed)
+		}
+	}
+
+	if result.StructuredContent == nil {
+		return
+	}
+	data, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		// Unencodable content would fail to send anyway; drop it rather than risk a leak
+		result.StructuredContent = nil
+		return
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		result.StructuredContent = nil
+		return
+	}
+	if cleaned, changed := redactValue(v, secrets); changed {
+		result.StructuredContent = cleaned
+	}
+}
+
+// RedactText masks secrets in s
+func (r *Redactor) RedactText(s string) string {
+	return redactText(s, r.secrets())
+}
+
+// secrets returns the configured secret values, longest first so that a
+// secret containing another is masked whole
+func (r *Redactor) secrets() []string {
+	var values []string
+	for _, v := range r.values() {
+		if len(v) >= minSecretLength {
+			values = append(values, v)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	return values
+}
+
+// redactTextRevealing redacts the text around each revealed value separately,
+// so the values themselves never pass through the patterns
+func redactTextRevealing(s string, secrets, revealed []string) string {
+	for i, v := range revealed {
+		if parts := strings.Split(s, v); len(parts) > 1 {
+			for j, part := range parts {
+				parts[j] = redactTextRevealing(part, secrets, revealed[i+1:])
+			}
+			return strings.Join(parts, v)
+		}
+	}
+	return redactText(s, secrets)
+}
+
+func redactText(s string, secrets []string) string {
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	for _, p := range secretPatterns {
+		s = p.re.ReplaceAllString(s, p.repl)
+	}
+	return s
+}
+
+// redactValue walks decoded JSON, masking secret fields and secret substrings
+func redactValue(v any, secrets []string) (any, bool) {
+	switch val := v.(type) {
+	case string:
+		cleaned := redactText(val, secrets)
+		return cleaned, cleaned != val
+	case []any:
+		changed := false
+		for i, item := range val {
+			cleaned, c := redactValue(item, secrets)
+			val[i] = cleaned
+			changed = changed || c
+		}
+		return val, changed
+	case map[string]any:
+		changed := false
+		for k, item := range val {
+			if s, ok := item.(string); ok && s != "" && isSecretField(k) {
+				val[k] = redacted
+				changed = true
+				continue
+			}
+			cleaned, c := redactValue(item, secrets)
+			val[k] = cleaned
+			changed = changed || c
+		}
+		return val, changed
+	}
+	return v, false
+}
+
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range secretFieldNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}